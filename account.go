@@ -2,6 +2,7 @@
 package gw2api
 
 import (
+	"encoding/json"
 	"time"
 )
 
@@ -47,6 +48,9 @@ type Account struct {
 	// The account's personal wvw rank
 	//* Requires the additional `progression` scope.
 	WvwRank int `json:"wvw_rank"`
+	// The WvW team and rank of the account. Before the Schema version
+	// 2024-07-20T01:00:00Z, only the rank is given, from wvw_rank.
+	Wvw AccountWvw `json:"wvw"`
 	// An ISO-8601 standard timestamp of when the account information last
 	// changed as perceived by the API. This field is only present when a
 	// Schema version of 2019-02-21T00:00:00Z or later is requested.
@@ -54,6 +58,31 @@ type Account struct {
 	LastModified time.Time `json:"last_modified"`
 }
 
+type AccountWvw struct {
+	// The id of the WvW team the account is assigned to.
+	TeamID int `json:"team_id"`
+	// The account's personal wvw rank
+	//* Requires the additional `progression` scope.
+	Rank int `json:"rank"`
+}
+
+// UnmarshalJSON decodes the account of any supported schema, giving the wvw
+// rank in both WvwRank and Wvw.Rank.
+func (a *Account) UnmarshalJSON(data []byte) error {
+	type account Account
+	if err := json.Unmarshal(data, (*account)(a)); err != nil {
+		return err
+	}
+
+	if a.Wvw.Rank == 0 {
+		a.Wvw.Rank = a.WvwRank
+	}
+	if a.WvwRank == 0 {
+		a.WvwRank = a.Wvw.Rank
+	}
+	return nil
+}
+
 type AccountAchievement struct {
 	// The achievement id.
 	ID int `json:"id"`
@@ -229,13 +258,20 @@ type AccountCurrency struct {
 	Value int `json:"value"`
 }
 
+// AccountResource returns the Resource of /account.
+func AccountResource(r *Requestor) Resource[Account] {
+	return NewResource[Account](r, "/account")
+}
+
 // This resource returns information about player accounts.
 // This endpoint is only accessible with a valid API key.
 func (r *Requestor) Account(account *Account) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionAccount).
-		request("/account", nil, &account)
-	return r
+	return AccountResource(r).get(account)
+}
+
+// AccountAchievementsResource returns the Resource of /account/achievements.
+func AccountAchievementsResource(r *Requestor) Resource[[]*AccountAchievement] {
+	return NewResource[[]*AccountAchievement](r, "/account/achievements")
 }
 
 // This resource returns an account's progress towards all their achievements.
@@ -243,10 +279,12 @@ func (r *Requestor) Account(account *Account) *Requestor {
 // and how far the player has progressed. For each achievement,
 // the following object is given:
 func (r *Requestor) AccountAchievements(achievements *[]*AccountAchievement) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/achievements", nil, &achievements)
-	return r
+	return AccountAchievementsResource(r).get(achievements)
+}
+
+// AccountBankResource returns the Resource of /account/bank.
+func AccountBankResource(r *Requestor) Resource[[]*InventoryItem] {
+	return NewResource[[]*InventoryItem](r, "/account/bank")
 }
 
 // This resource returns the items stored in a player's vault
@@ -257,10 +295,12 @@ func (r *Requestor) AccountAchievements(achievements *[]*AccountAchievement) *Re
 // If a slot is empty, it will return null. The amount of slots/bank tabs is
 // implied by the length of the array.
 func (r *Requestor) AccountBank(accountBank *[]*InventoryItem) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionInventory).
-		request("/account/bank", nil, &accountBank)
-	return r
+	return AccountBankResource(r).get(accountBank)
+}
+
+// AccountBuildStoragesEndpoint returns the Endpoint of /account/buildstorage.
+func AccountBuildStoragesEndpoint(r *Requestor) Endpoint[int, AccountBuildStorage] {
+	return NewEndpoint[int, AccountBuildStorage](r, "/account/buildstorage")
 }
 
 // This resource returns IDs of the templates stored in a player's build storage.
@@ -269,10 +309,7 @@ func (r *Requestor) AccountBank(accountBank *[]*InventoryItem) *Requestor {
 // slot in the build storage. The amount of templates is implied by the
 // length of the array.
 func (r *Requestor) AccountBuildStorageIDs(pointer *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionAccount).
-		collectionIDs("/account/buildstorage", &pointer)
-	return r
+	return AccountBuildStoragesEndpoint(r).ids(pointer)
 }
 
 // This resource returns the templates stored in a player's build storage.
@@ -281,10 +318,14 @@ func (r *Requestor) AccountBuildStorageIDs(pointer *[]int) *Requestor {
 // slot in the build storage. The amount of templates is implied by the
 // length of the array.
 func (r *Requestor) AccountBuildStorages(pointer *[]*AccountBuildStorage, ids ...int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionAccount).
-		collection("/account/buildstorage", &pointer, ids)
-	return r
+	return AccountBuildStoragesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns all the templates stored in a player's build storage.
+// This endpoint is only accessible with a valid API key.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllAccountBuildStorages(pointer *[]*AccountBuildStorage) *Requestor {
+	return AccountBuildStoragesEndpoint(r).all(pointer)
 }
 
 // This resource returns the templates stored in a player's build storage.
@@ -293,10 +334,12 @@ func (r *Requestor) AccountBuildStorages(pointer *[]*AccountBuildStorage, ids ..
 // slot in the build storage. The amount of templates is implied by the
 // length of the array.
 func (r *Requestor) AccountBuildStorage(pointer *AccountBuildStorage, id int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionAccount).
-		singleton("/account/buildstorage", &pointer, id)
-	return r
+	return AccountBuildStoragesEndpoint(r).get(pointer, id)
+}
+
+// AccountDailyCraftingResource returns the Resource of /account/dailycrafting.
+func AccountDailyCraftingResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/dailycrafting")
 }
 
 // This resource returns information about time-gated
@@ -307,10 +350,12 @@ func (r *Requestor) AccountBuildStorage(pointer *AccountBuildStorage, id int) *R
 // If no timed-gated recipes have been crafted since daily-reset by the account,
 // it will return an empty array ([]).
 func (r *Requestor) AccountDailyCrafting(accountDailyCraft *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/dailycrafting", nil, &accountDailyCraft)
-	return r
+	return AccountDailyCraftingResource(r).get(accountDailyCraft)
+}
+
+// AccountDungeonsResource returns the Resource of /account/dungeons.
+func AccountDungeonsResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/dungeons")
 }
 
 // This resource returns the dungeons completed since daily dungeon reset.
@@ -318,10 +363,12 @@ func (r *Requestor) AccountDailyCrafting(accountDailyCraft *[]string) *Requestor
 // that can be resolved against /v2/dungeons. Note that this ID indicates a
 // path and not the dungeon itself.
 func (r *Requestor) AccountDungeons(accountDungeons *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/dungeons", nil, &accountDungeons)
-	return r
+	return AccountDungeonsResource(r).get(accountDungeons)
+}
+
+// AccountDyesResource returns the Resource of /account/dyes.
+func AccountDyesResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/dyes")
 }
 
 // This resource returns the unlocked dyes of the account.
@@ -329,28 +376,36 @@ func (r *Requestor) AccountDungeons(accountDungeons *[]string) *Requestor {
 // The endpoint returns an array, each value being the ID
 // of a color resolved against /v2/colors.
 func (r *Requestor) AccountDyes(accountDyes *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/dyes", nil, &accountDyes)
-	return r
+	return AccountDyesResource(r).get(accountDyes)
+}
+
+// AccountEmotesResource returns the Resource of /account/emotes.
+func AccountEmotesResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/emotes")
 }
 
 // This resource returns the player's unlocked emotes.
 // This endpoint is only accessible with a valid API key.
 // The endpoint returns an array of strings, each representing an emote.
 func (r *Requestor) AccountEmotes(accountEmotes *[]string) *Requestor {
-	r.request("/account/emotes", nil, &accountEmotes)
-	return r
+	return AccountEmotesResource(r).get(accountEmotes)
+}
+
+// AccountFinishersResource returns the Resource of /account/finishers.
+func AccountFinishersResource(r *Requestor) Resource[[]*AccountFinisher] {
+	return NewResource[[]*AccountFinisher](r, "/account/finishers")
 }
 
 // This resource returns information about finishers that are unlocked
 // for an account.
 // This request will return an array of objects
 func (r *Requestor) AccountFinishers(accountFinishers *[]*AccountFinisher) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/finishers", nil, &accountFinishers)
-	return r
+	return AccountFinishersResource(r).get(accountFinishers)
+}
+
+// AccountGlidersResource returns the Resource of /account/gliders.
+func AccountGlidersResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/gliders")
 }
 
 // This resource returns information about gliders
@@ -358,17 +413,23 @@ func (r *Requestor) AccountFinishers(accountFinishers *[]*AccountFinisher) *Requ
 // This request will return an array of integer values
 // resolvable against /v2/gliders.
 func (r *Requestor) AccountGliders(accountGliders *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/gliders", nil, &accountGliders)
-	return r
+	return AccountGlidersResource(r).get(accountGliders)
+}
+
+// AccountHomeResource returns the Resource of /account/home.
+func AccountHomeResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/home")
 }
 
 // This resource returns a list of available sub-endpoints.
 // This request will return an array of the endpoints that may be requested.
 func (r *Requestor) AccountHome(accountHome *[]string) *Requestor {
-	r.request("/account/home", nil, &accountHome)
-	return r
+	return AccountHomeResource(r).get(accountHome)
+}
+
+// AccountHomeCatsResource returns the Resource of /account/home/cats.
+func AccountHomeCatsResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/home/cats")
 }
 
 // This resource returns information about unlocked home instance cats.
@@ -376,20 +437,24 @@ func (r *Requestor) AccountHome(accountHome *[]string) *Requestor {
 // Each integer represents the id of a particular cat that can be resolved
 // against /v2/home/cats.
 func (r *Requestor) AccountHomeCats(accountHomeCats *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/home/cats", nil, &accountHomeCats)
-	return r
+	return AccountHomeCatsResource(r).get(accountHomeCats)
+}
+
+// AccountHomeNodesResource returns the Resource of /account/home/nodes.
+func AccountHomeNodesResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/home/nodes")
 }
 
 // This resource returns information about unlocked home instance nodes.
 // This request will return an array of strings. Each string represents
 // the id of a particular node that can be resolved against /v2/home/nodes.
 func (r *Requestor) AccountHomeNodes(accountHomeNodes *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/home/nodes", nil, &accountHomeNodes)
-	return r
+	return AccountHomeNodesResource(r).get(accountHomeNodes)
+}
+
+// AccountInventoryResource returns the Resource of /account/inventory.
+func AccountInventoryResource(r *Requestor) Resource[[]*InventoryItem] {
+	return NewResource[[]*InventoryItem](r, "/account/inventory")
 }
 
 // This resource returns the shared inventory slots in an account.
@@ -398,20 +463,24 @@ func (r *Requestor) AccountHomeNodes(accountHomeNodes *[]string) *Requestor {
 // the shared inventory. If a slot is empty, it will return null.
 // The amount of slots is implied by the length of the array.
 func (r *Requestor) AccountInventory(accountInventory *[]*InventoryItem) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionInventory).
-		request("/account/inventory", nil, &accountInventory)
-	return r
+	return AccountInventoryResource(r).get(accountInventory)
+}
+
+// AccountLegendaryArmoryResource returns the Resource of /account/legendaryarmory.
+func AccountLegendaryArmoryResource(r *Requestor) Resource[[]*AccountLegendaryArmory] {
+	return NewResource[[]*AccountLegendaryArmory](r, "/account/legendaryarmory")
 }
 
 // This resource returns information about the Legendary Armory
 // items that are unlocked for an account.
 // This request will return an array of objects
 func (r *Requestor) AccountLegendaryArmory(accountLegendaryArmory *[]*AccountLegendaryArmory) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionInventory, TokenPermissionUnlocks).
-		request("/account/legendaryarmory", nil, &accountLegendaryArmory)
-	return r
+	return AccountLegendaryArmoryResource(r).get(accountLegendaryArmory)
+}
+
+// AccountLuckResource returns the Resource of /account/luck.
+func AccountLuckResource(r *Requestor) Resource[[]*AccountLuck] {
+	return NewResource[[]*AccountLuck](r, "/account/luck")
 }
 
 // This resource returns the total amount of luck consumed
@@ -420,10 +489,12 @@ func (r *Requestor) AccountLegendaryArmory(accountLegendaryArmory *[]*AccountLeg
 // NOTE: The response is an array due to the way luck is stored
 // internally on ArenaNET servers.
 func (r *Requestor) AccountLuck(accountLuck *[]*AccountLuck) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression, TokenPermissionUnlocks).
-		request("/account/luck", nil, &accountLuck)
-	return r
+	return AccountLuckResource(r).get(accountLuck)
+}
+
+// AccountMailCarriersResource returns the Resource of /account/mailcarriers.
+func AccountMailCarriersResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/mailcarriers")
 }
 
 // This resource returns information about mail carriers that are
@@ -431,10 +502,12 @@ func (r *Requestor) AccountLuck(accountLuck *[]*AccountLuck) *Requestor {
 // This request will return an array of integer values that can be
 // resolved against /v2/mailcarriers.
 func (r *Requestor) AccountMailCarriers(accountMailCarriers *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/mailcarriers", nil, &accountMailCarriers)
-	return r
+	return AccountMailCarriersResource(r).get(accountMailCarriers)
+}
+
+// AccountMapChestsResource returns the Resource of /account/mapchests.
+func AccountMapChestsResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/mapchests")
 }
 
 // This resource returns information about Hero's Choice Chests
@@ -445,10 +518,12 @@ func (r *Requestor) AccountMailCarriers(accountMailCarriers *[]int) *Requestor {
 // If no Hero's Choice Chest have been acquired since
 // daily-reset by the account, it will return an empty array ([]).
 func (r *Requestor) AccountMapChests(accountMapChests *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/mapchests", nil, &accountMapChests)
-	return r
+	return AccountMapChestsResource(r).get(accountMapChests)
+}
+
+// AccountMasteriesResource returns the Resource of /account/masteries.
+func AccountMasteriesResource(r *Requestor) Resource[[]*AccountMastery] {
+	return NewResource[[]*AccountMastery](r, "/account/masteries")
 }
 
 // This resource returns information about masteries that are unlocked
@@ -457,10 +532,12 @@ func (r *Requestor) AccountMapChests(accountMapChests *[]string) *Requestor {
 // found at /v2/account/mastery/points.
 // This request will return an array of objects
 func (r *Requestor) AccountMasteries(accountMasteries *[]*AccountMastery) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/masteries", nil, &accountMasteries)
-	return r
+	return AccountMasteriesResource(r).get(accountMasteries)
+}
+
+// AccountMasteryPointsResource returns the Resource of /account/mastery/points.
+func AccountMasteryPointsResource(r *Requestor) Resource[AccountMasteryPoint] {
+	return NewResource[AccountMasteryPoint](r, "/account/mastery/points")
 }
 
 // This resource returns information about the total amount of masteries
@@ -468,10 +545,12 @@ func (r *Requestor) AccountMasteries(accountMasteries *[]*AccountMastery) *Reque
 // break down is available at /v2/account/masteries.
 // This request will return an object
 func (r *Requestor) AccountMasteryPoints(accountMasteryPoints *AccountMasteryPoint) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/mastery/points", nil, &accountMasteryPoints)
-	return r
+	return AccountMasteryPointsResource(r).get(accountMasteryPoints)
+}
+
+// AccountMaterialsResource returns the Resource of /account/materials.
+func AccountMaterialsResource(r *Requestor) Resource[[]*AccountMaterial] {
+	return NewResource[[]*AccountMaterial](r, "/account/materials")
 }
 
 // This resource returns the materials stored in a player's vault.
@@ -480,10 +559,12 @@ func (r *Requestor) AccountMasteryPoints(accountMasteryPoints *AccountMasteryPoi
 // that can be stored in the vault. Every material will be returned,
 // even if they have a count of 0.
 func (r *Requestor) AccountMaterials(accountMaterials *[]*AccountMaterial) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionInventory).
-		request("/account/materials", nil, &accountMaterials)
-	return r
+	return AccountMaterialsResource(r).get(accountMaterials)
+}
+
+// AccountMinisResource returns the Resource of /account/minis.
+func AccountMinisResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/minis")
 }
 
 // This resource returns the unlocked miniatures of the account.
@@ -491,17 +572,23 @@ func (r *Requestor) AccountMaterials(accountMaterials *[]*AccountMaterial) *Requ
 // The endpoint returns an array, each value being the ID of a
 // miniature that can be resolved against /v2/minis.
 func (r *Requestor) AccountMinis(accountMinis *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/minis", nil, &accountMinis)
-	return r
+	return AccountMinisResource(r).get(accountMinis)
+}
+
+// AccountMountsResource returns the Resource of /account/mounts.
+func AccountMountsResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/mounts")
 }
 
 // This resource returns a list of available sub-endpoints.
 // This request will return an array of the endpoints that may be requested.
 func (r *Requestor) AccountMounts(accountMounts *[]string) *Requestor {
-	r.request("/account/mounts", nil, &accountMounts)
-	return r
+	return AccountMountsResource(r).get(accountMounts)
+}
+
+// AccountMountsSkinsResource returns the Resource of /account/mounts/skins.
+func AccountMountsSkinsResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/mounts/skins")
 }
 
 // This resource returns the unlocked mount skins of the account.
@@ -509,10 +596,12 @@ func (r *Requestor) AccountMounts(accountMounts *[]string) *Requestor {
 // The endpoint returns an array of numbers which can be
 // resolved against to /v2/mounts/skins.
 func (r *Requestor) AccountMountsSkins(accountMountsSkins *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/mounts/skins", nil, &accountMountsSkins)
-	return r
+	return AccountMountsSkinsResource(r).get(accountMountsSkins)
+}
+
+// AccountMountsTypesResource returns the Resource of /account/mounts/types.
+func AccountMountsTypesResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/mounts/types")
 }
 
 // This resource returns the unlocked mounts of the account.
@@ -520,10 +609,12 @@ func (r *Requestor) AccountMountsSkins(accountMountsSkins *[]int) *Requestor {
 // The endpoint returns an array of strings (mount names) which
 // can be compared to /v2/mounts/types.
 func (r *Requestor) AccountMountsTypes(accountMountsTypes *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/mounts/types", nil, &accountMountsTypes)
-	return r
+	return AccountMountsTypesResource(r).get(accountMountsTypes)
+}
+
+// AccountNoveltiesResource returns the Resource of /account/novelties.
+func AccountNoveltiesResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/novelties")
 }
 
 // This resource returns information about novelties that are unlocked
@@ -531,10 +622,12 @@ func (r *Requestor) AccountMountsTypes(accountMountsTypes *[]string) *Requestor
 // This request will return an array of integer values
 // resolvable against /v2/novelties.
 func (r *Requestor) AccountNovelties(accountNovelties *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/novelties", nil, &accountNovelties)
-	return r
+	return AccountNoveltiesResource(r).get(accountNovelties)
+}
+
+// AccountOutfilsResource returns the Resource of /account/outfits.
+func AccountOutfilsResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/outfits")
 }
 
 // This resource returns information about outfits that are
@@ -542,10 +635,12 @@ func (r *Requestor) AccountNovelties(accountNovelties *[]int) *Requestor {
 // This request will return an array of integer values resolvable
 // against /v2/outfits.
 func (r *Requestor) AccountOutfils(accountOutfils *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/outfits", nil, &accountOutfils)
-	return r
+	return AccountOutfilsResource(r).get(accountOutfils)
+}
+
+// AccountPvpHeroesResource returns the Resource of /account/pvp/heroes.
+func AccountPvpHeroesResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/pvp/heroes")
 }
 
 // This resource returns information about pvp heroes that
@@ -553,10 +648,12 @@ func (r *Requestor) AccountOutfils(accountOutfils *[]int) *Requestor {
 // This request will return an array of integer values
 // resolvable against /v2/pvp/heroes.
 func (r *Requestor) AccountPvpHeroes(accountPvpHeroes *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/pvp/heroes", nil, &accountPvpHeroes)
-	return r
+	return AccountPvpHeroesResource(r).get(accountPvpHeroes)
+}
+
+// AccountRaidsResource returns the Resource of /account/raids.
+func AccountRaidsResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/raids")
 }
 
 // This resource returns the completed raid encounters
@@ -565,10 +662,12 @@ func (r *Requestor) AccountPvpHeroes(accountPvpHeroes *[]int) *Requestor {
 // of a raid encounter that can be resolved against /v2/raids.
 // Note that this ID indicates an encounter and not the raid wing itself.
 func (r *Requestor) AccountRaids(accountRaids *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/raids", nil, &accountRaids)
-	return r
+	return AccountRaidsResource(r).get(accountRaids)
+}
+
+// AccountReceipesResource returns the Resource of /account/recipes.
+func AccountReceipesResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/recipes")
 }
 
 // This resource returns information about recipes that
@@ -576,10 +675,12 @@ func (r *Requestor) AccountRaids(accountRaids *[]string) *Requestor {
 // The endpoint returns an array, each value being the ID of a
 // recipe that can be resolved against /v2/recipes.
 func (r *Requestor) AccountReceipes(accountReceipes *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/recipes", nil, &accountReceipes)
-	return r
+	return AccountReceipesResource(r).get(accountReceipes)
+}
+
+// AccountSkinsResource returns the Resource of /account/skins.
+func AccountSkinsResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/skins")
 }
 
 // This resource returns the unlocked skins of the account.
@@ -587,10 +688,12 @@ func (r *Requestor) AccountReceipes(accountReceipes *[]int) *Requestor {
 // The endpoint returns an array, each value being the ID of a skin
 // that can be resolved against /v2/skins.
 func (r *Requestor) AccountSkins(accountSkins *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/skins", nil, &accountSkins)
-	return r
+	return AccountSkinsResource(r).get(accountSkins)
+}
+
+// AccountTitlesResource returns the Resource of /account/titles.
+func AccountTitlesResource(r *Requestor) Resource[[]int] {
+	return NewResource[[]int](r, "/account/titles")
 }
 
 // This resource returns information about titles that are
@@ -598,20 +701,24 @@ func (r *Requestor) AccountSkins(accountSkins *[]int) *Requestor {
 // This request will return an array of integer values
 // resolvable against /v2/titles.
 func (r *Requestor) AccountTitles(accountTitles *[]int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionUnlocks).
-		request("/account/titles", nil, &accountTitles)
-	return r
+	return AccountTitlesResource(r).get(accountTitles)
+}
+
+// AccountWalletResource returns the Resource of /account/wallet.
+func AccountWalletResource(r *Requestor) Resource[[]*AccountCurrency] {
+	return NewResource[[]*AccountCurrency](r, "/account/wallet")
 }
 
 // This resource returns the currencies of the account.
 // This endpoint is only accessible with a valid API key.
 // The endpoint returns an array of objects, each representing a currency.
 func (r *Requestor) AccountWallet(accountWallet *[]*AccountCurrency) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionWallet).
-		request("/account/wallet", nil, &accountWallet)
-	return r
+	return AccountWalletResource(r).get(accountWallet)
+}
+
+// AccountWorldBossesResource returns the Resource of /account/worldbosses.
+func AccountWorldBossesResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/account/worldbosses")
 }
 
 // This resource returns information about which world bosses have been
@@ -622,8 +729,5 @@ func (r *Requestor) AccountWallet(accountWallet *[]*AccountCurrency) *Requestor
 // If no bosses have been killed since daily-reset by the account,
 // it will return an empty array ([]).
 func (r *Requestor) AccountWorldBosses(accountWorldBosses *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionProgression).
-		request("/account/worldbosses", nil, &accountWorldBosses)
-	return r
+	return AccountWorldBossesResource(r).get(accountWorldBosses)
 }
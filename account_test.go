@@ -1,10 +1,10 @@
 package gw2api_test
 
 import (
-	"os"
 	"testing"
 
 	"atomys.codes/gw2api-go"
+	"atomys.codes/gw2api-go/gw2apitest"
 )
 
 func TestAccount(t *testing.T) {
@@ -13,17 +13,13 @@ func TestAccount(t *testing.T) {
 		apiKey  string
 		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, false},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if tt.apiKey == "" {
-				t.Skip("Requestor.Account() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var account gw2api.Account
 			if got := r.Auth(tt.apiKey).Account(&account).Err(); (got != nil) != tt.wantErr {
@@ -35,7 +31,7 @@ func TestAccount(t *testing.T) {
 
 func TestAccountWithoutAuth(t *testing.T) {
 	var account gw2api.Account
-	err := gw2api.NewRequestor().Account(&account).Err()
+	err := newRequestor().Account(&account).Err()
 	if err != gw2api.ErrRequireAuthentication {
 		t.Errorf("Requestor.Account() = %v, want %v", err, gw2api.ErrRequireAuthentication)
 	}
@@ -43,23 +39,18 @@ func TestAccountWithoutAuth(t *testing.T) {
 
 func TestAccountAchievements(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountAchievements() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var achievements []*gw2api.AccountAchievement
 			if got := r.Auth(tt.apiKey).AccountAchievements(&achievements).Err(); (got != nil) != tt.wantErr {
@@ -71,23 +62,18 @@ func TestAccountAchievements(t *testing.T) {
 
 func TestAccountBank(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountBank() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountBank []*gw2api.InventoryItem
 			if got := r.Auth(tt.apiKey).AccountBank(&accountBank).Err(); (got != nil) != tt.wantErr {
@@ -99,23 +85,18 @@ func TestAccountBank(t *testing.T) {
 
 func TestAccountBuildStorageIDs(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_INVALID"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyInvalid, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountBuildStorageIDs() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountBuildStorage []int
 			if got := r.Auth(tt.apiKey).AccountBuildStorageIDs(&accountBuildStorage).Err(); (got != nil) != tt.wantErr {
@@ -127,23 +108,18 @@ func TestAccountBuildStorageIDs(t *testing.T) {
 
 func TestAccountBuildStorages(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_INVALID"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyInvalid, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountBuildStorages() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountBuildStorage []*gw2api.AccountBuildStorage
 			if got := r.Auth(tt.apiKey).AccountBuildStorages(&accountBuildStorage, 1, 2).Err(); (got != nil) != tt.wantErr {
@@ -155,23 +131,18 @@ func TestAccountBuildStorages(t *testing.T) {
 
 func TestAccountBuildStorage(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_INVALID"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyInvalid, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountBuildStorage() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountBuildStorage gw2api.AccountBuildStorage
 			if got := r.Auth(tt.apiKey).AccountBuildStorage(&accountBuildStorage, 1).Err(); (got != nil) != tt.wantErr {
@@ -183,23 +154,18 @@ func TestAccountBuildStorage(t *testing.T) {
 
 func TestAccountDailyCrafting(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountDailyCrafting() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountDailyCraft []string
 			if got := r.Auth(tt.apiKey).AccountDailyCrafting(&accountDailyCraft).Err(); (got != nil) != tt.wantErr {
@@ -211,23 +177,18 @@ func TestAccountDailyCrafting(t *testing.T) {
 
 func TestAccountDungeons(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountDungeons() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountDungeons []string
 			if got := r.Auth(tt.apiKey).AccountDungeons(&accountDungeons).Err(); (got != nil) != tt.wantErr {
@@ -239,23 +200,18 @@ func TestAccountDungeons(t *testing.T) {
 
 func TestAccountDyes(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountDyes() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var accountDyes []int
 			if got := r.Auth(tt.apiKey).AccountDyes(&accountDyes).Err(); (got != nil) != tt.wantErr {
@@ -267,23 +223,18 @@ func TestAccountDyes(t *testing.T) {
 
 func TestAccountEmotes(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountEmotes() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountEmotes(&result).Err(); (got != nil) != tt.wantErr {
@@ -295,23 +246,18 @@ func TestAccountEmotes(t *testing.T) {
 
 func TestAccountFinishers(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountFinishers() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.AccountFinisher
 			if got := r.Auth(tt.apiKey).AccountFinishers(&result).Err(); (got != nil) != tt.wantErr {
@@ -323,23 +269,18 @@ func TestAccountFinishers(t *testing.T) {
 
 func TestAccountGliders(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountGliders() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountGliders(&result).Err(); (got != nil) != tt.wantErr {
@@ -351,23 +292,18 @@ func TestAccountGliders(t *testing.T) {
 
 func TestAccountHome(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_INVALID"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyInvalid, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountHome() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountHome(&result).Err(); (got != nil) != tt.wantErr {
@@ -379,23 +315,18 @@ func TestAccountHome(t *testing.T) {
 
 func TestAccountHomeCats(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountHomeCats() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountHomeCats(&result).Err(); (got != nil) != tt.wantErr {
@@ -407,23 +338,18 @@ func TestAccountHomeCats(t *testing.T) {
 
 func TestAccountHomeNodes(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountHomeNodes() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountHomeNodes(&result).Err(); (got != nil) != tt.wantErr {
@@ -435,23 +361,18 @@ func TestAccountHomeNodes(t *testing.T) {
 
 func TestAccountInventory(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountInventory() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.InventoryItem
 			if got := r.Auth(tt.apiKey).AccountInventory(&result).Err(); (got != nil) != tt.wantErr {
@@ -463,23 +384,18 @@ func TestAccountInventory(t *testing.T) {
 
 func TestAccountLegendaryArmory(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountLegendaryArmory() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.AccountLegendaryArmory
 			if got := r.Auth(tt.apiKey).AccountLegendaryArmory(&result).Err(); (got != nil) != tt.wantErr {
@@ -491,23 +407,18 @@ func TestAccountLegendaryArmory(t *testing.T) {
 
 func TestAccountLuck(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountLuck() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.AccountLuck
 			if got := r.Auth(tt.apiKey).AccountLuck(&result).Err(); (got != nil) != tt.wantErr {
@@ -519,23 +430,18 @@ func TestAccountLuck(t *testing.T) {
 
 func TestAccountMailCarriers(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMailCarriers() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountMailCarriers(&result).Err(); (got != nil) != tt.wantErr {
@@ -547,23 +453,18 @@ func TestAccountMailCarriers(t *testing.T) {
 
 func TestAccountMapChests(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMapChests() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountMapChests(&result).Err(); (got != nil) != tt.wantErr {
@@ -575,23 +476,18 @@ func TestAccountMapChests(t *testing.T) {
 
 func TestAccountMasteries(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMasteries() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.AccountMastery
 			if got := r.Auth(tt.apiKey).AccountMasteries(&result).Err(); (got != nil) != tt.wantErr {
@@ -603,23 +499,18 @@ func TestAccountMasteries(t *testing.T) {
 
 func TestAccountMasteryPoints(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMasteryPoints() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result gw2api.AccountMasteryPoint
 			if got := r.Auth(tt.apiKey).AccountMasteryPoints(&result).Err(); (got != nil) != tt.wantErr {
@@ -631,23 +522,18 @@ func TestAccountMasteryPoints(t *testing.T) {
 
 func TestAccountMaterials(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMaterials() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.AccountMaterial
 			if got := r.Auth(tt.apiKey).AccountMaterials(&result).Err(); (got != nil) != tt.wantErr {
@@ -659,23 +545,18 @@ func TestAccountMaterials(t *testing.T) {
 
 func TestAccountMinis(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMinis() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountMinis(&result).Err(); (got != nil) != tt.wantErr {
@@ -687,23 +568,18 @@ func TestAccountMinis(t *testing.T) {
 
 func TestAccountMounts(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_INVALID"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyInvalid, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMounts() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountMounts(&result).Err(); (got != nil) != tt.wantErr {
@@ -715,23 +591,18 @@ func TestAccountMounts(t *testing.T) {
 
 func TestAccountMountsSkins(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMountsSkins() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountMountsSkins(&result).Err(); (got != nil) != tt.wantErr {
@@ -743,23 +614,18 @@ func TestAccountMountsSkins(t *testing.T) {
 
 func TestAccountMountsTypes(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountMountsTypes() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountMountsTypes(&result).Err(); (got != nil) != tt.wantErr {
@@ -771,23 +637,18 @@ func TestAccountMountsTypes(t *testing.T) {
 
 func TestAccountNovelties(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountNovelties() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountNovelties(&result).Err(); (got != nil) != tt.wantErr {
@@ -799,23 +660,18 @@ func TestAccountNovelties(t *testing.T) {
 
 func TestAccountOutfils(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountOutfils() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountOutfils(&result).Err(); (got != nil) != tt.wantErr {
@@ -827,23 +683,18 @@ func TestAccountOutfils(t *testing.T) {
 
 func TestAccountPvpHeroes(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountPvpHeroes() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountPvpHeroes(&result).Err(); (got != nil) != tt.wantErr {
@@ -855,23 +706,18 @@ func TestAccountPvpHeroes(t *testing.T) {
 
 func TestAccountRaids(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountRaids() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountRaids(&result).Err(); (got != nil) != tt.wantErr {
@@ -883,23 +729,18 @@ func TestAccountRaids(t *testing.T) {
 
 func TestAccountReceipes(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountReceipes() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountReceipes(&result).Err(); (got != nil) != tt.wantErr {
@@ -911,23 +752,18 @@ func TestAccountReceipes(t *testing.T) {
 
 func TestAccountSkins(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountSkins() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountSkins(&result).Err(); (got != nil) != tt.wantErr {
@@ -939,23 +775,18 @@ func TestAccountSkins(t *testing.T) {
 
 func TestAccountTitles(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountTitles() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []int
 			if got := r.Auth(tt.apiKey).AccountTitles(&result).Err(); (got != nil) != tt.wantErr {
@@ -967,23 +798,18 @@ func TestAccountTitles(t *testing.T) {
 
 func TestAccountWallet(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountWallet() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []*gw2api.AccountCurrency
 			if got := r.Auth(tt.apiKey).AccountWallet(&result).Err(); (got != nil) != tt.wantErr {
@@ -995,23 +821,18 @@ func TestAccountWallet(t *testing.T) {
 
 func TestAccountWorldBosses(t *testing.T) {
 	tests := []struct {
-		name       string
-		apiKey     string
-		withoutApi bool
-		wantErr    bool
+		name    string
+		apiKey  string
+		wantErr bool
 	}{
-		{"with valid api_key", os.Getenv("API_KEY_VALID"), false, false},
-		{"with invalid api_key", os.Getenv("API_KEY_MISSING_SCOPE"), false, true},
-		{"without api_key", "", true, true},
+		{"with valid api_key", gw2apitest.KeyValid, false},
+		{"with invalid api_key", gw2apitest.KeyMissingScope, true},
+		{"without api_key", "", true},
 	}
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			r := gw2api.NewRequestor()
-
-			if !tt.withoutApi && tt.apiKey == "" {
-				t.Skip("Requestor.AccountWorldBosses() = Cannot test without api key")
-			}
+			r := newRequestor()
 
 			var result []string
 			if got := r.Auth(tt.apiKey).AccountWorldBosses(&result).Err(); (got != nil) != tt.wantErr {
@@ -146,47 +146,74 @@ type AchievementsGroup struct {
 	Categories []int `json:"categories"`
 }
 
+// AchievementsEndpoint returns the Endpoint of /achievements.
+func AchievementsEndpoint(r *Requestor) Endpoint[int, Achievement] {
+	return NewEndpoint[int, Achievement](r, "/achievements")
+}
+
 // This resource returns all achievements in the game,
 // including localized names and icons.
 // A list of all ids is returned.
 func (r *Requestor) AchievementIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/achievements", &pointer)
-	return r
+	return AchievementsEndpoint(r).ids(pointer)
 }
 
 // This resource returns all achievements in the game,
 // When multiple ids are requested using the ids
 // parameter, a list of response objects is returned.
 func (r *Requestor) Achievements(pointer *[]*Achievement, ids ...int) *Requestor {
-	r.collection("/achievements", &pointer, ids)
-	return r
+	return AchievementsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns all achievements in the game,
+// Return a Paginator over the pages of `pageSize` achievements.
+func (r *Requestor) AchievementsPaginator(pageSize int) *Paginator {
+	return r.paginator("/achievements", pageSize)
+}
+
+// This resource returns all achievements in the game,
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllAchievements(pointer *[]*Achievement) *Requestor {
+	return AchievementsEndpoint(r).all(pointer)
 }
 
 // This resource returns an achievement in the game by her ID,
 func (r *Requestor) Achievement(pointer *Achievement, id int) *Requestor {
-	r.singleton("/achievements", &pointer, id)
-	return r
+	return AchievementsEndpoint(r).get(pointer, id)
+}
+
+// AchievementsCategoriesEndpoint returns the Endpoint of /achievements/categories.
+func AchievementsCategoriesEndpoint(r *Requestor) Endpoint[int, AchievementsCategory] {
+	return NewEndpoint[int, AchievementsCategory](r, "/achievements/categories")
 }
 
 // This resource returns all achievements categories in the game,
 // A list of all ids is returned.
 func (r *Requestor) AchievementsCategoryIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/achievements/categories", &pointer)
-	return r
+	return AchievementsCategoriesEndpoint(r).ids(pointer)
 }
 
 // This resource returns all achievements categories in the game,
 // When multiple ids are requested using the ids
 // parameter, a list of response objects is returned.
 func (r *Requestor) AchievementsCategories(pointer *[]*AchievementsCategory, ids ...int) *Requestor {
-	r.collection("/achievements/categories", &pointer, ids)
-	return r
+	return AchievementsCategoriesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns all achievements categories in the game,
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllAchievementsCategories(pointer *[]*AchievementsCategory) *Requestor {
+	return AchievementsCategoriesEndpoint(r).all(pointer)
 }
 
 // This resource returns an achievement category in the game by her ID,
 func (r *Requestor) AchievementsCategory(pointer *AchievementsCategory, id int) *Requestor {
-	r.singleton("/achievements/categories", &pointer, id)
-	return r
+	return AchievementsCategoriesEndpoint(r).get(pointer, id)
+}
+
+// AchievementsDailyResource returns the Resource of /achievements/daily.
+func AchievementsDailyResource(r *Requestor) Resource[AchievementsDailyStructure] {
+	return NewResource[AchievementsDailyStructure](r, "/achievements/daily")
 }
 
 // This resource returns the current set of daily achievements.
@@ -196,8 +223,12 @@ func (r *Requestor) AchievementsCategory(pointer *AchievementsCategory, id int)
 // achievements for that category. The special sub-object is for any current
 // temporary content like festival dailies
 func (r *Requestor) AchievementsDaily(achievementsDaily *AchievementsDailyStructure) *Requestor {
-	r.request("/achievements/daily", nil, &achievementsDaily)
-	return r
+	return AchievementsDailyResource(r).get(achievementsDaily)
+}
+
+// AchievementsDailyTomorrowResource returns the Resource of /achievements/daily/tomorrow.
+func AchievementsDailyTomorrowResource(r *Requestor) Resource[AchievementsDailyStructure] {
+	return NewResource[AchievementsDailyStructure](r, "/achievements/daily/tomorrow")
 }
 
 // This resource returns the next set of daily achievements.
@@ -207,27 +238,34 @@ func (r *Requestor) AchievementsDaily(achievementsDaily *AchievementsDailyStruct
 // achievements for that category. The special sub-object is for any current
 // temporary content like festival dailies
 func (r *Requestor) AchievementsDailyTomorrow(achievementsDaily *AchievementsDailyStructure) *Requestor {
-	r.request("/achievements/daily/tomorrow", nil, &achievementsDaily)
-	return r
+	return AchievementsDailyTomorrowResource(r).get(achievementsDaily)
+}
+
+// AchievementsGroupsEndpoint returns the Endpoint of /achievements/groups.
+func AchievementsGroupsEndpoint(r *Requestor) Endpoint[string, AchievementsGroup] {
+	return NewEndpoint[string, AchievementsGroup](r, "/achievements/groups")
 }
 
 // This resource returns all the top-level groups for achievements.
 // A list of all ids is returned.
-func (r *Requestor) AchievementsGroupIDs(pointer []string) *Requestor {
-	r.collectionIDs("/achievements/groups", &pointer)
-	return r
+func (r *Requestor) AchievementsGroupIDs(pointer *[]string) *Requestor {
+	return AchievementsGroupsEndpoint(r).ids(pointer)
 }
 
 // This resource returns all the top-level groups for achievements.
 // When multiple ids are requested using the ids
 // parameter, a list of response objects is returned.
 func (r *Requestor) AchievementsGroups(pointer *[]*AchievementsGroup, ids ...string) *Requestor {
-	r.collection("/achievements/groups", &pointer, ids)
-	return r
+	return AchievementsGroupsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns all the top-level groups for achievements.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllAchievementsGroups(pointer *[]*AchievementsGroup) *Requestor {
+	return AchievementsGroupsEndpoint(r).all(pointer)
 }
 
 // This resource returns a top level group for achievements in the game by her ID,
 func (r *Requestor) AchievementsGroup(pointer *AchievementsGroup, id string) *Requestor {
-	r.singleton("/achievements/groups", &pointer, id)
-	return r
+	return AchievementsGroupsEndpoint(r).get(pointer, id)
 }
@@ -17,8 +17,8 @@ func TestRequestor_Achievement(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"achievement with correct id", gw2api.NewRequestor(), args{gw2api.Achievement{}, 1}, false},
-		{"achievement with invalid id", gw2api.NewRequestor(), args{gw2api.Achievement{}, 0}, true},
+		{"achievement with correct id", newRequestor(), args{gw2api.Achievement{}, 1}, false},
+		{"achievement with invalid id", newRequestor(), args{gw2api.Achievement{}, 0}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,7 +39,7 @@ func TestRequestor_AchievementIDs(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"AchievementIDs", gw2api.NewRequestor(), args{[]int{}}, false},
+		{"AchievementIDs", newRequestor(), args{[]int{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -60,10 +60,10 @@ func TestRequestor_Achievements(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"all achievements", gw2api.NewRequestor(), args{nil}, true},
-		{"no achievements given", gw2api.NewRequestor(), args{[]int{}}, true},
-		{"one or more achievements", gw2api.NewRequestor(), args{[]int{1}}, false},
-		{"one or more invalid ids", gw2api.NewRequestor(), args{[]int{-1, -5}}, true},
+		{"all achievements", newRequestor(), args{nil}, true},
+		{"no achievements given", newRequestor(), args{[]int{}}, true},
+		{"one or more achievements", newRequestor(), args{[]int{1}}, false},
+		{"one or more invalid ids", newRequestor(), args{[]int{-1, -5}}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -86,8 +86,8 @@ func TestRequestor_AchievementsCategory(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"achievementsCategory with correct id", gw2api.NewRequestor(), args{gw2api.AchievementsCategory{}, 1}, false},
-		{"achievementsCategory with invalid id", gw2api.NewRequestor(), args{gw2api.AchievementsCategory{}, 0}, true},
+		{"achievementsCategory with correct id", newRequestor(), args{gw2api.AchievementsCategory{}, 1}, false},
+		{"achievementsCategory with invalid id", newRequestor(), args{gw2api.AchievementsCategory{}, 0}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -108,7 +108,7 @@ func TestRequestor_AchievementsCategoryIDs(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"AchievementsCategoryIDs", gw2api.NewRequestor(), args{[]int{}}, false},
+		{"AchievementsCategoryIDs", newRequestor(), args{[]int{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -129,10 +129,10 @@ func TestRequestor_AchievementsCategorys(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"all achievementsCategorys", gw2api.NewRequestor(), args{nil}, true},
-		{"no achievementsCategorys given", gw2api.NewRequestor(), args{[]int{}}, true},
-		{"one or more achievementsCategorys", gw2api.NewRequestor(), args{[]int{1}}, false},
-		{"one or more invalid ids", gw2api.NewRequestor(), args{[]int{-1, -5}}, true},
+		{"all achievementsCategorys", newRequestor(), args{nil}, true},
+		{"no achievementsCategorys given", newRequestor(), args{[]int{}}, true},
+		{"one or more achievementsCategorys", newRequestor(), args{[]int{1}}, false},
+		{"one or more invalid ids", newRequestor(), args{[]int{-1, -5}}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -154,7 +154,7 @@ func TestRequestor_AchievementsDaily(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"AchievementsDaily", gw2api.NewRequestor(), args{gw2api.AchievementsDailyStructure{}}, false},
+		{"AchievementsDaily", newRequestor(), args{gw2api.AchievementsDailyStructure{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -175,7 +175,7 @@ func TestRequestor_AchievementsDailyTomorrow(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"AchievementsDailyTomorrow", gw2api.NewRequestor(), args{gw2api.AchievementsDailyStructure{}}, false},
+		{"AchievementsDailyTomorrow", newRequestor(), args{gw2api.AchievementsDailyStructure{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -197,8 +197,8 @@ func TestRequestor_AchievementsGroup(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"achievementsGroup with correct id", gw2api.NewRequestor(), args{gw2api.AchievementsGroup{}, "A4ED8379-5B6B-4ECC-B6E1-70C350C902D2"}, false},
-		{"achievementsGroup with invalid id", gw2api.NewRequestor(), args{gw2api.AchievementsGroup{}, "a"}, true},
+		{"achievementsGroup with correct id", newRequestor(), args{gw2api.AchievementsGroup{}, "A4ED8379-5B6B-4ECC-B6E1-70C350C902D2"}, false},
+		{"achievementsGroup with invalid id", newRequestor(), args{gw2api.AchievementsGroup{}, "a"}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -219,11 +219,11 @@ func TestRequestor_AchievementsGroupIDs(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"AchievementsGroupIDs", gw2api.NewRequestor(), args{[]string{}}, false},
+		{"AchievementsGroupIDs", newRequestor(), args{[]string{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.requestor.AchievementsGroupIDs(tt.args.achievementsGroupIDs).Err(); (got != nil) != tt.wantErr {
+			if got := tt.requestor.AchievementsGroupIDs(&tt.args.achievementsGroupIDs).Err(); (got != nil) != tt.wantErr {
 				t.Errorf("Requestor.AchievementsGroup() = %v, want error %v", got, tt.wantErr)
 			}
 		})
@@ -240,10 +240,10 @@ func TestRequestor_AchievementsGroups(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"all achievementsGroups", gw2api.NewRequestor(), args{nil}, true},
-		{"no achievementsGroups given", gw2api.NewRequestor(), args{[]string{}}, true},
-		{"one or more achievementsGroups", gw2api.NewRequestor(), args{[]string{"A4ED8379-5B6B-4ECC-B6E1-70C350C902D2"}}, false},
-		{"one or more invalid ids", gw2api.NewRequestor(), args{[]string{"-1", "-5"}}, true},
+		{"all achievementsGroups", newRequestor(), args{nil}, true},
+		{"no achievementsGroups given", newRequestor(), args{[]string{}}, true},
+		{"one or more achievementsGroups", newRequestor(), args{[]string{"A4ED8379-5B6B-4ECC-B6E1-70C350C902D2"}}, false},
+		{"one or more invalid ids", newRequestor(), args{[]string{"-1", "-5"}}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
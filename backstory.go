@@ -25,7 +25,7 @@ type BackstoryAnswer struct {
 
 type BackstoryQuestion struct {
 	// The id of the question.
-	ID string `json:"id"`
+	ID int `json:"id"`
 	// The title (or name) of the question.
 	Title string `json:"title"`
 	// The description of the question; as displayed in-game when
@@ -45,38 +45,54 @@ type BackstoryQuestion struct {
 	Races []string `json:"races"`
 }
 
+// BackstoryAnswersEndpoint returns the Endpoint of /backstory/answers.
+func BackstoryAnswersEndpoint(r *Requestor) Endpoint[string, BackstoryAnswer] {
+	return NewEndpoint[string, BackstoryAnswer](r, "/backstory/answers")
+}
+
 // This resource returns information about the Biography answers that are in the game.
 func (r *Requestor) BackstoryAnswer(pointer *BackstoryAnswer, id string) *Requestor {
-	r.singleton("/backstory/answers", &pointer, id)
-	return r
+	return BackstoryAnswersEndpoint(r).get(pointer, id)
 }
 
 // This resource returns information about the Biography answers that are in the game.
 func (r *Requestor) BackstoryAnswers(pointer *[]*BackstoryAnswer, ids ...string) *Requestor {
-	r.collection("/backstory/answers", &pointer, ids)
-	return r
+	return BackstoryAnswersEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the Biography answers that are in the game.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllBackstoryAnswers(pointer *[]*BackstoryAnswer) *Requestor {
+	return BackstoryAnswersEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the Biography answers that are in the game.
 func (r *Requestor) BackstoryAnswersIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/backstory/answers", &pointer)
-	return r
+	return BackstoryAnswersEndpoint(r).ids(pointer)
+}
+
+// BackstoryQuestionsEndpoint returns the Endpoint of /backstory/questions.
+func BackstoryQuestionsEndpoint(r *Requestor) Endpoint[int, BackstoryQuestion] {
+	return NewEndpoint[int, BackstoryQuestion](r, "/backstory/questions")
 }
 
 // This resource returns information about the Biography questions that are in the game.
 func (r *Requestor) BackstoryQuestion(pointer *BackstoryQuestion, id int) *Requestor {
-	r.singleton("/backstory/questions", &pointer, id)
-	return r
+	return BackstoryQuestionsEndpoint(r).get(pointer, id)
 }
 
 // This resource returns information about the Biography questions that are in the game.
 func (r *Requestor) BackstoryQuestions(pointer *[]*BackstoryQuestion, ids ...int) *Requestor {
-	r.collection("/backstory/questions", &pointer, ids)
-	return r
+	return BackstoryQuestionsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the Biography questions that are in the game.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllBackstoryQuestions(pointer *[]*BackstoryQuestion) *Requestor {
+	return BackstoryQuestionsEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the Biography questions that are in the game.
 func (r *Requestor) BackstoryQuestionsIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/backstory/questions", &pointer)
-	return r
+	return BackstoryQuestionsEndpoint(r).ids(pointer)
 }
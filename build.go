@@ -6,10 +6,14 @@ type Build struct {
 	ID int `json:"id"`
 }
 
+// BuildResource returns the Resource of /build.
+func BuildResource(r *Requestor) Resource[Build] {
+	return NewResource[Build](r, "/build")
+}
+
 // This resource returns the current build id of the game.
 // This can be used, for example, to register when event timers reset
 // due to server restarts.
 func (r *Requestor) Build(build *Build, id int) *Requestor {
-	r.request("/builds", nil, &build)
-	return r
+	return BuildResource(r).get(build)
 }
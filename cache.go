@@ -0,0 +1,311 @@
+package gw2api
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+const (
+	// DefaultCacheTTL is the time a response is kept in the cache to be
+	// revalidated, when its max-age is shorter.
+	DefaultCacheTTL = 24 * time.Hour
+	// DefaultBuildCheckInterval is the interval between two checks of the
+	// game build id, used to invalidate the cached responses.
+	DefaultBuildCheckInterval = 5 * time.Minute
+)
+
+// Cache stores the responses of the API. Implementations must be safe for
+// concurrent use. A cache failure must be reported as a miss by Get.
+type Cache interface {
+	// Get returns the entry stored under the key, if any and not expired.
+	Get(key string) (CacheEntry, bool)
+	// Set stores the entry under the key for the given time to live.
+	Set(key string, entry CacheEntry, ttl time.Duration)
+	// Delete removes the entry stored under the key.
+	Delete(key string)
+}
+
+// CacheEntry is a response stored in a cache with its validators.
+type CacheEntry struct {
+	// The ETag sent by the API, replayed in the If-None-Match header.
+	ETag string `json:"etag"`
+	// The raw body of the response.
+	Body []byte `json:"body"`
+	// The headers of the response.
+	Header http.Header `json:"header"`
+	// The time until the entry can be used without asking the API,
+	// computed from the Cache-Control max-age directive.
+	Expires time.Time `json:"expires"`
+	// The game build id reported by /build when the entry was stored.
+	Build int `json:"build"`
+}
+
+// fresh reports whether the entry can be used without revalidation.
+func (e CacheEntry) fresh() bool {
+	return time.Now().Before(e.Expires)
+}
+
+// MemoryCache is an in-memory Cache, safe for concurrent use.
+// It can be shared between several Requestors.
+type MemoryCache struct {
+	mu      sync.RWMutex
+	entries map[string]memoryCacheEntry
+}
+
+type memoryCacheEntry struct {
+	CacheEntry
+	deadline time.Time
+}
+
+// NewMemoryCache returns an empty MemoryCache.
+func NewMemoryCache() *MemoryCache {
+	return &MemoryCache{entries: make(map[string]memoryCacheEntry)}
+}
+
+func (c *MemoryCache) Get(key string) (CacheEntry, bool) {
+	c.mu.RLock()
+	entry, ok := c.entries[key]
+	c.mu.RUnlock()
+
+	if ok && time.Now().After(entry.deadline) {
+		c.deleteExpired(key, entry.deadline)
+		return CacheEntry{}, false
+	}
+	return entry.CacheEntry, ok
+}
+
+// deleteExpired deletes the entry stored under the key if it still is the
+// expired one, not replaced by a concurrent Set.
+func (c *MemoryCache) deleteExpired(key string, deadline time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if entry, ok := c.entries[key]; ok && entry.deadline.Equal(deadline) {
+		delete(c.entries, key)
+	}
+}
+
+func (c *MemoryCache) Set(key string, entry CacheEntry, ttl time.Duration) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.entries[key] = memoryCacheEntry{CacheEntry: entry, deadline: time.Now().Add(ttl)}
+}
+
+func (c *MemoryCache) Delete(key string) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.entries, key)
+}
+
+// Cache enables the HTTP conditional caching of the responses in the given
+// cache. Cached responses are reused while their Cache-Control max-age is not
+// expired, then revalidated with their ETag. Entries stored under a previous
+// game build are invalidated. Give a nil cache to disable it.
+func (r *Requestor) Cache(cache Cache) *Requestor {
+	r = r.derive()
+	r.cache = cache
+	if r.build == nil {
+		r.build = &buildWatcher{interval: DefaultBuildCheckInterval}
+	}
+	return r
+}
+
+// CacheTTL sets the time a response is kept in the cache. Responses with a
+// longer max-age are kept until it expires.
+func (r *Requestor) CacheTTL(ttl time.Duration) *Requestor {
+	r = r.derive()
+	r.cacheTTL = ttl
+	return r
+}
+
+// BuildCheck sets the interval between two checks of the game build id
+// reported by /build. Cached entries stored under another build are
+// invalidated. Give a zero interval to disable the checks.
+func (r *Requestor) BuildCheck(interval time.Duration) *Requestor {
+	r = r.derive()
+	r.build = &buildWatcher{interval: interval}
+	return r
+}
+
+// buildWatcher tracks the current game build id. It is shared by the copies
+// of a Requestor.
+type buildWatcher struct {
+	mu       sync.Mutex
+	interval time.Duration
+	checked  time.Time
+	fetching bool
+	id       int
+}
+
+// currentBuild returns the current game build id, fetching it from /build
+// when the last check is older than the interval. A single fetch is done at
+// a time, the lookups during the fetch using the last known id. The fetch is
+// not cancelled with the context of the Requestor, and a failed fetch is
+// retried by the next lookup. It returns 0 when the build id is unknown.
+func (r *Requestor) currentBuild() int {
+	w := r.build
+	if w == nil || w.interval <= 0 {
+		return 0
+	}
+
+	w.mu.Lock()
+	if w.fetching || (!w.checked.IsZero() && time.Since(w.checked) < w.interval) {
+		id := w.id
+		w.mu.Unlock()
+		return id
+	}
+	w.fetching = true
+	w.mu.Unlock()
+
+	requestor := r.derive()
+	requestor.cache = nil
+	requestor.token = ""
+	requestor.subtoken = nil
+	requestor.poolKey = nil
+	requestor.poolToken = ""
+	requestor.context = context.WithoutCancel(r.context)
+	requestor.err = nil
+
+	var build Build
+	err := requestor.request("/build", nil, &build).Err()
+
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.fetching = false
+	if err == nil && build.ID != 0 {
+		w.id = build.ID
+		w.checked = time.Now()
+	}
+	return w.id
+}
+
+// cacheKey returns the key identifying the request in the cache: its URL,
+// lang, schema version and a hash of the token.
+func (r *Requestor) cacheKey(url string) string {
+	var tokenHash string
+	if token := r.authToken(); token != "" {
+		sum := sha256.Sum256([]byte(token))
+		tokenHash = hex.EncodeToString(sum[:8])
+	}
+
+	return strings.Join([]string{url, string(r.lang), string(r.schemaVersion), tokenHash}, "|")
+}
+
+// cachedEntry looks up the cache for the request. A fresh entry is returned
+// to be used as is, otherwise its ETag is set on the request to revalidate it.
+func (r *Requestor) cachedEntry(req *http.Request, key string) (entry *CacheEntry, fresh bool) {
+	if r.cache == nil {
+		return nil, false
+	}
+
+	e, ok := r.cache.Get(key)
+	if !ok {
+		return nil, false
+	}
+	// The entries stored while the build was unknown are invalidated once
+	// it is known.
+	if build := r.currentBuild(); build != 0 && e.Build != build {
+		r.cache.Delete(key)
+		return nil, false
+	}
+	if e.fresh() {
+		return &e, true
+	}
+
+	if e.ETag != "" {
+		req.Header.Set("If-None-Match", e.ETag)
+	}
+	return &e, false
+}
+
+// readBody reads the body of a successful response. The body of the cached
+// entry is replayed on a 304 Not Modified, and the cache is updated with the
+// new validators of the response.
+func (r *Requestor) readBody(response *http.Response, key string, cached *CacheEntry) ([]byte, error) {
+	var body []byte
+	if response.StatusCode == http.StatusNotModified {
+		if cached != nil {
+			body = cached.Body
+		}
+	} else {
+		var err error
+		if body, err = io.ReadAll(response.Body); err != nil {
+			return nil, err
+		}
+	}
+
+	if r.cache == nil || body == nil || response.StatusCode == http.StatusPartialContent {
+		return body, nil
+	}
+
+	maxAge, store := cacheControl(response.Header.Get("Cache-Control"))
+	etag := response.Header.Get("ETag")
+	if etag == "" && cached != nil && response.StatusCode == http.StatusNotModified {
+		etag = cached.ETag
+	}
+	if store && (etag != "" || maxAge > 0) {
+		ttl := r.cacheTTL
+		if maxAge > ttl {
+			ttl = maxAge
+		}
+		r.cache.Set(key, CacheEntry{
+			ETag:    etag,
+			Body:    body,
+			Header:  responseHeader(response, cached),
+			Expires: time.Now().Add(maxAge),
+			Build:   r.currentBuild(),
+		}, ttl)
+	}
+
+	return body, nil
+}
+
+// responseHeader returns the headers of the response. On a 304 Not Modified,
+// the headers missing from the response are taken from the cached entry.
+func responseHeader(response *http.Response, cached *CacheEntry) http.Header {
+	if response.StatusCode != http.StatusNotModified || cached == nil {
+		return response.Header
+	}
+
+	header := cached.Header.Clone()
+	if header == nil {
+		header = http.Header{}
+	}
+	for key, values := range response.Header {
+		header[key] = values
+	}
+	return header
+}
+
+// cacheControl parses the Cache-Control header and returns its max-age and
+// whether the response can be stored.
+func cacheControl(header string) (maxAge time.Duration, store bool) {
+	var noCache bool
+	for _, directive := range strings.Split(header, ",") {
+		directive = strings.ToLower(strings.TrimSpace(directive))
+		switch {
+		case directive == "no-store":
+			return 0, false
+		case directive == "no-cache":
+			noCache = true
+		case strings.HasPrefix(directive, "max-age="):
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil && seconds > 0 {
+				maxAge = time.Duration(seconds) * time.Second
+			}
+		}
+	}
+
+	if noCache {
+		maxAge = 0
+	}
+	return maxAge, true
+}
@@ -0,0 +1,272 @@
+package gw2api_test
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestRequestor_Cache(t *testing.T) {
+	tests := []struct {
+		name         string
+		cacheControl string
+		wantCalls    int
+		wantRequests []string
+	}{
+		{"revalidates with etag", "no-cache", 2, []string{"", `"v1"`}},
+		{"reuses fresh responses", "public, max-age=300", 1, []string{""}},
+		{"does not store with no-store", "no-store", 2, []string{"", ""}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if req.URL.Path == "/v2/build" {
+					return jsonResponse(req, http.StatusOK, `{"id":115267}`), nil
+				}
+
+				etag := req.Header.Get("If-None-Match")
+				requests = append(requests, etag)
+
+				response := jsonResponse(req, http.StatusOK, `{"id":2101,"name":"Jade Sea [FR]"}`)
+				if etag == `"v1"` {
+					response = jsonResponse(req, http.StatusNotModified, ``)
+				}
+				response.Header.Set("ETag", `"v1"`)
+				response.Header.Set("Cache-Control", tt.cacheControl)
+				return response, nil
+			})
+
+			r := gw2api.NewRequestor().Transport(transport).Cache(gw2api.NewMemoryCache())
+			for i := 0; i < 2; i++ {
+				var world gw2api.World
+				if err := r.World(&world, 2101).Err(); err != nil {
+					t.Fatalf("Requestor.World() = %v, want no error", err)
+				}
+				if world.Name != "Jade Sea [FR]" {
+					t.Errorf("World.Name = %q on call %d, want %q", world.Name, i+1, "Jade Sea [FR]")
+				}
+			}
+
+			if len(requests) != tt.wantCalls {
+				t.Fatalf("transport called %d times, want %d", len(requests), tt.wantCalls)
+			}
+			for i, want := range tt.wantRequests {
+				if requests[i] != want {
+					t.Errorf("If-None-Match on call %d = %q, want %q", i+1, requests[i], want)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestor_CacheKey(t *testing.T) {
+	var calls int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/v2/build" {
+			return jsonResponse(req, http.StatusOK, `{"id":115267}`), nil
+		}
+
+		calls++
+		response := jsonResponse(req, http.StatusOK, `{"id":1}`)
+		response.Header.Set("Cache-Control", "max-age=300")
+		return response, nil
+	})
+
+	cache := gw2api.NewMemoryCache()
+	var title gw2api.Title
+	gw2api.NewRequestor().Transport(transport).Cache(cache).Lang(gw2api.LangFR).Title(&title, 1)
+	gw2api.NewRequestor().Transport(transport).Cache(cache).Lang(gw2api.LangEN).Title(&title, 1)
+	gw2api.NewRequestor().Transport(transport).Cache(cache).Lang(gw2api.LangEN).Title(&title, 1)
+
+	if calls != 2 {
+		t.Errorf("transport called %d times, want 2", calls)
+	}
+}
+
+func TestFileCache(t *testing.T) {
+	dir := t.TempDir()
+	entry := gw2api.CacheEntry{ETag: `"v1"`, Body: []byte(`{"id":1}`), Build: 115267}
+
+	cache, err := gw2api.NewFileCache(dir)
+	if err != nil {
+		t.Fatalf("NewFileCache() = %v, want no error", err)
+	}
+	cache.Set("live", entry, time.Hour)
+	cache.Set("expired", entry, -time.Second)
+	cache.Set("deleted", entry, time.Hour)
+	cache.Delete("deleted")
+
+	// A new FileCache on the same directory simulates a restart.
+	cache, _ = gw2api.NewFileCache(dir)
+	tests := []struct {
+		key    string
+		wantOk bool
+	}{
+		{"live", true},
+		{"expired", false},
+		{"deleted", false},
+		{"unknown", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			got, ok := cache.Get(tt.key)
+			if ok != tt.wantOk {
+				t.Fatalf("FileCache.Get() ok = %v, want %v", ok, tt.wantOk)
+			}
+			if ok && (got.ETag != entry.ETag || string(got.Body) != string(entry.Body) || got.Build != entry.Build) {
+				t.Errorf("FileCache.Get() = %+v, want %+v", got, entry)
+			}
+		})
+	}
+}
+
+func TestRequestor_CacheBuildInvalidation(t *testing.T) {
+	build := 115267
+	var calls int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/v2/build" {
+			return jsonResponse(req, http.StatusOK, fmt.Sprintf(`{"id":%d}`, build)), nil
+		}
+
+		calls++
+		response := jsonResponse(req, http.StatusOK, `{"id":1}`)
+		response.Header.Set("Cache-Control", "max-age=300")
+		return response, nil
+	})
+
+	cache, err := gw2api.NewFileCache(t.TempDir())
+	if err != nil {
+		t.Fatalf("NewFileCache() = %v, want no error", err)
+	}
+
+	var story gw2api.Story
+	for _, b := range []int{115267, 115267, 115300} {
+		build = b
+		r := gw2api.NewRequestor().Transport(transport).Cache(cache).BuildCheck(time.Nanosecond)
+		if err := r.Story(&story, 1).Err(); err != nil {
+			t.Fatalf("Requestor.Story() = %v, want no error", err)
+		}
+	}
+
+	if calls != 2 {
+		t.Errorf("transport called %d times, want 2", calls)
+	}
+}
+
+func TestRequestor_CacheBuildCheckConcurrent(t *testing.T) {
+	var blocking atomic.Bool
+	release := make(chan struct{})
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/v2/build" {
+			if blocking.Load() {
+				<-release
+			}
+			return jsonResponse(req, http.StatusOK, `{"id":115267}`), nil
+		}
+
+		response := jsonResponse(req, http.StatusOK, `{"id":1}`)
+		response.Header.Set("Cache-Control", "max-age=300")
+		return response, nil
+	})
+
+	r := gw2api.NewRequestor().Transport(transport).Cache(gw2api.NewMemoryCache()).BuildCheck(time.Nanosecond)
+	var story gw2api.Story
+	if err := r.Story(&story, 1).Err(); err != nil {
+		t.Fatalf("Requestor.Story() = %v, want no error", err)
+	}
+
+	// While a lookup checks the build, the others use the last known one.
+	blocking.Store(true)
+	done := make(chan error)
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var story gw2api.Story
+			done <- r.Story(&story, 1).Err()
+		}()
+	}
+	for i := 0; i < 4; i++ {
+		select {
+		case err := <-done:
+			if err != nil {
+				t.Errorf("Requestor.Story() = %v, want no error", err)
+			}
+		case <-time.After(2 * time.Second):
+			t.Fatal("cache lookups blocked by the build check")
+		}
+	}
+	close(release)
+	<-done
+	wg.Wait()
+}
+
+func TestRequestor_CacheUnknownBuild(t *testing.T) {
+	buildStatus := http.StatusInternalServerError
+	var builds, calls int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/v2/build" {
+			builds++
+			return jsonResponse(req, buildStatus, `{"id":115267}`), nil
+		}
+
+		calls++
+		response := jsonResponse(req, http.StatusOK, `{"id":1}`)
+		response.Header.Set("Cache-Control", "max-age=300")
+		return response, nil
+	})
+	r := gw2api.NewRequestor().Transport(transport).Cache(gw2api.NewMemoryCache()).BuildCheck(time.Hour)
+
+	// The entry stored while /build fails is invalidated once the build is
+	// known, the failed check being retried by the next lookup.
+	var story gw2api.Story
+	for i, status := range []int{http.StatusInternalServerError, http.StatusOK, http.StatusOK} {
+		buildStatus = status
+		if err := r.Story(&story, 1).Err(); err != nil {
+			t.Fatalf("request %d = %v, want no error", i, err)
+		}
+	}
+	if builds != 2 || calls != 2 {
+		t.Errorf("requested /build %d times and the story %d times, want 2 and 2", builds, calls)
+	}
+}
+
+func TestRequestor_CacheBuildCheckContext(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	var builds int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if req.URL.Path == "/v2/build" {
+			builds++
+			// The caller gives up while the build is checked.
+			cancel()
+			if err := req.Context().Err(); err != nil {
+				t.Errorf("/build requested with the context of the caller: %v", err)
+				return nil, err
+			}
+			return jsonResponse(req, http.StatusOK, `{"id":115267}`), nil
+		}
+		response := jsonResponse(req, http.StatusOK, `{"id":1}`)
+		response.Header.Set("Cache-Control", "max-age=300")
+		return response, nil
+	})
+	r := gw2api.NewRequestor().Transport(transport).Cache(gw2api.NewMemoryCache()).BuildCheck(time.Hour)
+
+	var story gw2api.Story
+	r.WithContext(ctx).Story(&story, 1)
+	for i := 0; i < 2; i++ {
+		if err := r.Story(&story, 1).Err(); err != nil {
+			t.Fatalf("Requestor.Story() = %v, want no error", err)
+		}
+	}
+	if builds != 1 {
+		t.Errorf("requested /build %d times, want 1", builds)
+	}
+}
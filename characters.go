@@ -2,6 +2,7 @@
 package gw2api
 
 import (
+	"encoding/json"
 	"fmt"
 	"time"
 )
@@ -166,11 +167,11 @@ type CharacterSkill struct {
 
 type CharacterSpecializations struct {
 	// contains the information on each slotted specialization and trait for PvE
-	Pve CharacterSpecialization `json:"pve"`
+	Pve []CharacterSpecialization `json:"pve"`
 	// contains the information on each slotted specialization and trait for PvP
-	Pvp CharacterSpecialization `json:"pvp"`
+	Pvp []CharacterSpecialization `json:"pvp"`
 	// contains the information on each slotted specialization and trait for WvW
-	Wvw CharacterSpecialization `json:"wvw"`
+	Wvw []CharacterSpecialization `json:"wvw"`
 }
 
 type CharacterSpecialization struct {
@@ -187,7 +188,7 @@ type CharacterTraining struct {
 	// Shows how many hero points have been spent in this tree
 	Spent int `json:"spent"`
 	// States whether or not the tree is fully trained.
-	Done int `json:"done"`
+	Done bool `json:"done"`
 }
 
 type CharacterSAB struct {
@@ -280,8 +281,52 @@ type CharacterSummary struct {
 	// and traits equipped
 	Specializations CharacterSpecializations `json:"specializations"`
 	// contains objects for each skill tree trained
-	Training CharacterTraining `json:"training"`
+	Training []CharacterTraining `json:"training"`
 	CharacterExtra
+
+	// The build and equipment tabs of the character. These fields are only
+	// present when a Schema version of 2019-12-19T00:00:00Z or later is
+	// requested.
+	ActiveBuildTab        int                     `json:"active_build_tab"`
+	ActiveEquipmentTab    int                     `json:"active_equipment_tab"`
+	BuildTabsUnlocked     int                     `json:"build_tabs_unlocked"`
+	EquipmentTabsUnlocked int                     `json:"equipment_tabs_unlocked"`
+	BuildTabs             []CharacterBuildTab     `json:"build_tabs"`
+	EquipmentTabs         []CharacterEquipmentTab `json:"equipment_tabs"`
+}
+
+// UnmarshalJSON decodes the character of any supported schema. Since the
+// schema 2019-12-19T00:00:00Z, the skills and specializations are only given
+// by the build tabs: they are filled from the active build tab, which applies
+// to every game mode.
+func (c *CharacterSummary) UnmarshalJSON(data []byte) error {
+	type characterSummary CharacterSummary
+	if err := json.Unmarshal(data, (*characterSummary)(c)); err != nil {
+		return err
+	}
+
+	for _, tab := range c.BuildTabs {
+		if !tab.IsActive {
+			continue
+		}
+
+		if c.Specializations.Pve == nil {
+			specializations := make([]CharacterSpecialization, len(tab.Build.Specializations))
+			for i, specialization := range tab.Build.Specializations {
+				specializations[i] = CharacterSpecialization(specialization)
+			}
+			c.Specializations = CharacterSpecializations{Pve: specializations, Pvp: specializations, Wvw: specializations}
+		}
+		if c.Skills.Pve.Heal == 0 {
+			skill := CharacterSkill{
+				Heal:      tab.Build.Skills.Heal,
+				Utilities: tab.Build.Skills.Utilities,
+				Elite:     tab.Build.Skills.Elite,
+			}
+			c.Skills = CharacterSkills{Pve: skill, Pvp: skill, Wvw: skill}
+		}
+	}
+	return nil
 }
 
 type CharacterBackstorySummary struct {
@@ -327,7 +372,7 @@ type CharacterSpecializationsSummary struct {
 
 type CharacterTrainingSummary struct {
 	// Contains objects for each skill tree trained
-	Training CharacterTraining `json:"training"`
+	Training []CharacterTraining `json:"training"`
 }
 
 type CharacterEquipmentTab struct {
@@ -338,200 +383,239 @@ type CharacterEquipmentTab struct {
 	// Whether or not this is the tab selected on the character currently.
 	IsActive bool `json:"is_active"`
 	// Contains an object for each equiped piece of equipment
-	Equipment CharacterEquipment `json:"equipment"`
+	Equipment []CharacterEquipment `json:"equipment"`
 	// Contains the following key-value pairs
 	EquipmentPvp CharacterExtraEquipmentPvp `json:"equipment_pvp"`
 }
 
+// CharactersEndpoint returns the Endpoint of /characters.
+func CharactersEndpoint(r *Requestor) Endpoint[string, CharacterSummary] {
+	return NewEndpoint[string, CharacterSummary](r, "/characters")
+}
+
 // This resource returns information about characters attached to a
 // specific account.
 // It will return an array of characters name.
 func (r *Requestor) CharactersName(pointer *[]string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		collectionIDs("/characters", &pointer)
-	return r
+	return CharactersEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about characters attached to a
 // specific account.
 // It will return a characters summary specific by name.
 func (r *Requestor) Character(pointer *CharacterSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		singleton("/characters", &pointer, name)
-	return r
+	return CharactersEndpoint(r).get(pointer, name)
 }
 
 // This resource returns information about characters attached to a
 // specific account.
 // It will return an array of characters summary.
 func (r *Requestor) Characters(pointer *[]*CharacterSummary, ids ...string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		collection("/characters", &pointer, ids)
-	return r
+	return CharactersEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about characters attached to a
+// specific account.
+// It will return an array of all the characters summary.
+func (r *Requestor) AllCharacters(pointer *[]*CharacterSummary) *Requestor {
+	return CharactersEndpoint(r).all(pointer)
+}
+
+// CharacterBackstoryResource returns the Resource of /characters/:name/backstory.
+func CharacterBackstoryResource(r *Requestor, name string) Resource[CharacterBackstorySummary] {
+	return NewResource[CharacterBackstorySummary](r, fmt.Sprintf("/characters/%s/backstory", name))
 }
 
 // An object containing an array of strings representing backstory answer IDs
 // pertaining to the questions answered during character creation.
 // References /v2/backstory/answers.
 func (r *Requestor) CharacterBackstory(pointer *CharacterBackstorySummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		request(fmt.Sprintf("/characters/%s/backstory", name), nil, &pointer)
-	return r
+	return CharacterBackstoryResource(r, name).get(pointer)
+}
+
+// CharacterCoreResource returns the Resource of /characters/:name/core.
+func CharacterCoreResource(r *Requestor, name string) Resource[Character] {
+	return NewResource[Character](r, fmt.Sprintf("/characters/%s/core", name))
 }
 
 // This resource returns core information about a character attached
 // to a specific account.
 func (r *Requestor) CharacterCore(pointer *Character, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		request(fmt.Sprintf("/characters/%s/core", name), nil, &pointer)
-	return r
+	return CharacterCoreResource(r, name).get(pointer)
+}
+
+// CharacterCraftingResource returns the Resource of /characters/:name/crafting.
+func CharacterCraftingResource(r *Requestor, name string) Resource[CharacterCraftingSummary] {
+	return NewResource[CharacterCraftingSummary](r, fmt.Sprintf("/characters/%s/crafting", name))
 }
 
 // This resource returns core information about a character attached
 // to a specific account.
 func (r *Requestor) CharacterCrafting(pointer *CharacterCraftingSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		request(fmt.Sprintf("/characters/%s/crafting", name), nil, &pointer)
-	return r
+	return CharacterCraftingResource(r, name).get(pointer)
+}
+
+// CharacterEquipmentResource returns the Resource of /characters/:name/equipment.
+func CharacterEquipmentResource(r *Requestor, name string) Resource[CharacterEquimentSummary] {
+	return NewResource[CharacterEquimentSummary](r, fmt.Sprintf("/characters/%s/equipment", name))
 }
 
 // This resource returns information about the equipment on a
 // character attached to a specific account.
 func (r *Requestor) CharacterEquipment(pointer *CharacterEquimentSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds, TokenPermissionInventory).
-		request(fmt.Sprintf("/characters/%s/equipment", name), nil, &pointer)
-	return r
+	return CharacterEquipmentResource(r, name).get(pointer)
+}
+
+// CharacterHeroPointsResource returns the Resource of /characters/:name/heropoints.
+func CharacterHeroPointsResource(r *Requestor, name string) Resource[[]string] {
+	return NewResource[[]string](r, fmt.Sprintf("/characters/%s/heropoints", name))
 }
 
 // This resource returns information about the hero points obtained by
 // a character attached to a specific account.
 func (r *Requestor) CharacterHeroPoints(pointer *[]string, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionProgression).
-		request(fmt.Sprintf("/characters/%s/heropoints", name), nil, &pointer)
-	return r
+	return CharacterHeroPointsResource(r, name).get(pointer)
+}
+
+// CharacterInventoryResource returns the Resource of /characters/:name/inventory.
+func CharacterInventoryResource(r *Requestor, name string) Resource[CharacterInventorySummary] {
+	return NewResource[CharacterInventorySummary](r, fmt.Sprintf("/characters/%s/inventory", name))
 }
 
 // This resource returns information about the hero points obtained by
 // a character attached to a specific account.
 func (r *Requestor) CharacterInventory(pointer *CharacterInventorySummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionInventory).
-		request(fmt.Sprintf("/characters/%s/inventory", name), nil, &pointer)
-	return r
+	return CharacterInventoryResource(r, name).get(pointer)
+}
+
+// CharacterQuestsResource returns the Resource of /characters/:name/quests.
+func CharacterQuestsResource(r *Requestor, name string) Resource[[]int] {
+	return NewResource[[]int](r, fmt.Sprintf("/characters/%s/quests", name))
 }
 
 // This resource returns information about the quests selected that by a
 // character attached to a specific account.
 func (r *Requestor) CharacterQuests(pointer *[]int, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionProgression).
-		request(fmt.Sprintf("/characters/%s/quests", name), nil, &pointer)
-	return r
+	return CharacterQuestsResource(r, name).get(pointer)
+}
+
+// CharacterRecipesResource returns the Resource of /characters/:name/recipes.
+func CharacterRecipesResource(r *Requestor, name string) Resource[CharacterReceipesSummary] {
+	return NewResource[CharacterReceipesSummary](r, fmt.Sprintf("/characters/%s/recipes", name))
 }
 
 // This resource returns information about recipes that the given
 // character can use.
 func (r *Requestor) CharacterRecipes(pointer *CharacterReceipesSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionInventory).
-		request(fmt.Sprintf("/characters/%s/recipes", name), nil, &pointer)
-	return r
+	return CharacterRecipesResource(r, name).get(pointer)
+}
+
+// CharacterSABResource returns the Resource of /characters/:name/sab.
+func CharacterSABResource(r *Requestor, name string) Resource[CharacterSAB] {
+	return NewResource[CharacterSAB](r, fmt.Sprintf("/characters/%s/sab", name))
 }
 
 // This resource returns information about Super Adventure Box on a
 // character attached to a specific account.
 func (r *Requestor) CharacterSAB(pointer *CharacterSAB, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter).
-		request(fmt.Sprintf("/characters/%s/sab", name), nil, &pointer)
-	return r
+	return CharacterSABResource(r, name).get(pointer)
+}
+
+// CharacterSkillsResource returns the Resource of /characters/:name/skills.
+func CharacterSkillsResource(r *Requestor, name string) Resource[CharacterSkillSummary] {
+	return NewResource[CharacterSkillSummary](r, fmt.Sprintf("/characters/%s/skills", name))
 }
 
 // This resource returns information about the skills equipped on
 // a character attached to a specific account.
 func (r *Requestor) CharacterSkills(pointer *CharacterSkillSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/skills", name), nil, &pointer)
-	return r
+	return CharacterSkillsResource(r, name).get(pointer)
+}
+
+// CharacterSpecializationsResource returns the Resource of /characters/:name/specializations.
+func CharacterSpecializationsResource(r *Requestor, name string) Resource[CharacterSpecializationsSummary] {
+	return NewResource[CharacterSpecializationsSummary](r, fmt.Sprintf("/characters/%s/specializations", name))
 }
 
 // This resource returns information about the specializations equipped on a
 // character attached to a specific account.
 func (r *Requestor) CharacterSpecializations(pointer *CharacterSpecializationsSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/specializations", name), nil, &pointer)
-	return r
+	return CharacterSpecializationsResource(r, name).get(pointer)
+}
+
+// CharacterTrainingResource returns the Resource of /characters/:name/training.
+func CharacterTrainingResource(r *Requestor, name string) Resource[CharacterTrainingSummary] {
+	return NewResource[CharacterTrainingSummary](r, fmt.Sprintf("/characters/%s/training", name))
 }
 
 // This resource returns information about the training of a character
 // attached to a specific account.
 func (r *Requestor) CharacterTraining(pointer *CharacterTrainingSummary, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/training", name), nil, &pointer)
-	return r
+	return CharacterTrainingResource(r, name).get(pointer)
+}
+
+// CharacterBuildTabsIDsResource returns the Resource of /characters/:name/buildtabs.
+func CharacterBuildTabsIDsResource(r *Requestor, name string) Resource[[]int] {
+	return NewResource[[]int](r, fmt.Sprintf("/characters/%s/buildtabs", name))
 }
 
 // This resource returns information about an accounts build template tabs.
 // Request a list of all available tabs.
 func (r *Requestor) CharacterBuildTabsIDs(pointer *[]int, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/buildtabs", name), nil, &pointer)
-	return r
+	return CharacterBuildTabsIDsResource(r, name).get(pointer)
+}
+
+// CharacterBuildTabResource returns the Resource of /characters/:name/buildtabs/:id.
+func CharacterBuildTabResource(r *Requestor, name string, id int) Resource[CharacterBuildTab] {
+	return NewResource[CharacterBuildTab](r, fmt.Sprintf("/characters/%s/buildtabs/%d", name, id))
 }
 
 // This resource returns information about an accounts build template tabs.
 // Request information about the specified tab only.
 func (r *Requestor) CharacterBuildTab(pointer *CharacterBuildTab, name string, id int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/buildtabs/%d", name, id), nil, &pointer)
-	return r
+	return CharacterBuildTabResource(r, name, id).get(pointer)
+}
+
+// CharacterActiveBuildTabResource returns the Resource of /characters/:name/buildtabs/active.
+func CharacterActiveBuildTabResource(r *Requestor, name string) Resource[CharacterBuildTab] {
+	return NewResource[CharacterBuildTab](r, fmt.Sprintf("/characters/%s/buildtabs/active", name))
 }
 
 // This resource returns information about an accounts build template tabs.
 // Request information about the currently selected tab only.
 func (r *Requestor) CharacterActiveBuildTab(pointer *CharacterBuildTab, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/buildtabs/active", name), nil, &pointer)
-	return r
+	return CharacterActiveBuildTabResource(r, name).get(pointer)
+}
+
+// CharacterEquipmentTabsIDsResource returns the Resource of /characters/:name/equipmenttabs.
+func CharacterEquipmentTabsIDsResource(r *Requestor, name string) Resource[[]int] {
+	return NewResource[[]int](r, fmt.Sprintf("/characters/%s/equipmenttabs", name))
 }
 
 // This resource returns information about an accounts equipment template tabs.
 // Request a list of all available tabs.
 func (r *Requestor) CharacterEquipmentTabsIDs(pointer *[]int, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/equipmenttabs", name), nil, &pointer)
-	return r
+	return CharacterEquipmentTabsIDsResource(r, name).get(pointer)
+}
+
+// CharacterEquipmentTabResource returns the Resource of /characters/:name/equipmenttabs/:id.
+func CharacterEquipmentTabResource(r *Requestor, name string, id int) Resource[CharacterEquipmentTab] {
+	return NewResource[CharacterEquipmentTab](r, fmt.Sprintf("/characters/%s/equipmenttabs/%d", name, id))
 }
 
 // This resource returns information about an accounts equipment template tabs.
 // Request information about the specified tab only.
 func (r *Requestor) CharacterEquipmentTab(pointer *CharacterEquipmentTab, name string, id int) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/equipmenttabs/%d", name, id), nil, &pointer)
-	return r
+	return CharacterEquipmentTabResource(r, name, id).get(pointer)
+}
+
+// CharacterActiveEquipmentTabResource returns the Resource of /characters/:name/equipmenttabs/active.
+func CharacterActiveEquipmentTabResource(r *Requestor, name string) Resource[CharacterEquipmentTab] {
+	return NewResource[CharacterEquipmentTab](r, fmt.Sprintf("/characters/%s/equipmenttabs/active", name))
 }
 
 // This resource returns information about an accounts equipment template tabs.
 // Request information about the currently selected tab only.
 func (r *Requestor) CharacterActiveEquipmentTab(pointer *CharacterEquipmentTab, name string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionCharacter, TokenPermissionBuilds).
-		request(fmt.Sprintf("/characters/%s/equipmenttabs/active", name), nil, &pointer)
-	return r
+	return CharacterActiveEquipmentTabResource(r, name).get(pointer)
 }
@@ -1,38 +1,261 @@
 package gw2api
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
+	"net/http"
 	"net/url"
+	"reflect"
 	"strings"
+	"sync"
 )
 
+const (
+	// MaxCollectionIDs is the maximum amount of ids the API accepts in a
+	// single request.
+	MaxCollectionIDs = 200
+	// DefaultChunkConcurrency is the default amount of chunks of ids
+	// requested at the same time.
+	DefaultChunkConcurrency = 4
+)
+
+// ChunkConcurrency sets the amount of chunks of ids requested at the same
+// time when more than MaxCollectionIDs ids are requested.
+func (r *Requestor) ChunkConcurrency(n int) *Requestor {
+	if n < 1 {
+		n = 1
+	}
+	r = r.derive()
+	r.chunks = n
+	return r
+}
+
 // This resource returns a list of the dungeons
 // Return an array of ids for each type of currency.
 func (r *Requestor) collectionIDs(endpoint string, pointer interface{}) *Requestor {
-	r.request(endpoint, nil, &pointer)
-	return r
+	return r.request(endpoint, nil, &pointer)
 }
 
 // This resource returns a list of the dungeons
 // Return a list of response objects
+// The ids are split in chunks of MaxCollectionIDs requested concurrently,
+// then merged back in the given order into the pointer.
 func (r *Requestor) collection(endpoint string, pointer interface{}, ids ...interface{}) *Requestor {
-	if len(ids) == 0 {
-		r.err = errors.New("at least one id must be given")
+	sIds := idsToStrings(ids...)
+	if r.err != nil {
+		return r
+	}
+
+	if len(sIds) == 0 {
+		return r.fail(errors.New("at least one id must be given"))
+	}
+
+	if len(sIds) <= MaxCollectionIDs {
+		return r.request(endpoint, url.Values{"ids": []string{strings.Join(sIds, ",")}}, &pointer)
+	}
+
+	slice := sliceOf(pointer)
+	if !slice.IsValid() {
+		return r.fail(fmt.Errorf("cannot merge chunks of %s into %T", endpoint, pointer))
+	}
+
+	chunks := chunkIDs(sIds, MaxCollectionIDs)
+	results := make([]reflect.Value, len(chunks))
+	responses := make([]*ResponseMeta, len(chunks))
+	errs := make([]error, len(chunks))
+
+	var wg sync.WaitGroup
+	sem := make(chan struct{}, r.chunks)
+	for i, chunk := range chunks {
+		wg.Add(1)
+		sem <- struct{}{}
+		go func(i int, chunk []string) {
+			defer wg.Done()
+			defer func() { <-sem }()
+
+			result := reflect.New(slice.Type())
+			requestor := r.request(endpoint, url.Values{"ids": []string{strings.Join(chunk, ",")}}, result.Interface())
+			results[i], responses[i], errs[i] = result.Elem(), requestor.response, requestor.err
+		}(i, chunk)
+	}
+	wg.Wait()
+
+	// The response of the last chunk is kept, counting the results of all
+	// the chunks.
+	r = r.derive()
+	r.response = responses[len(responses)-1]
+	// A chunk of invalid ids only is answered with a 404, its ids are
+	// reported as missing like in a 206 Partial Content. When every id is
+	// invalid, the 404 is returned as for a single request.
+	var partialErr *PartialResultError
+	notFound := 0
+	for i, err := range errs {
+		var missing []string
+		var chunkErr *PartialResultError
+		switch {
+		case errors.As(err, &chunkErr):
+			missing = chunkErr.Missing
+		case errors.Is(err, ErrNotFound):
+			missing = chunks[i]
+			notFound++
+		case err != nil:
+			r.response, r.err = responses[i], err
+			return r
+		default:
+			continue
+		}
+		if partialErr == nil {
+			partialErr = &PartialResultError{Endpoint: endpoint}
+		}
+		partialErr.Missing = append(partialErr.Missing, missing...)
+	}
+	if notFound == len(chunks) {
+		r.response, r.err = responses[0], errs[0]
 		return r
 	}
+	if partialErr != nil {
+		r.err = partialErr
+	}
+	if r.response != nil {
+		response := *r.response
+		response.ResultCount = 0
+		for _, chunk := range responses {
+			response.ResultCount += chunk.ResultCount
+		}
+		r.response = &response
+	}
 
-	var urlValues url.Values
-	sIds := strings.Trim(strings.Replace(fmt.Sprint(ids...), " ", ",", -1), "[]")
-	urlValues = url.Values{"ids": strings.Split(sIds, ",")}
+	merged := reflect.MakeSlice(slice.Type(), 0, len(sIds))
+	for _, result := range results {
+		merged = reflect.AppendSlice(merged, result)
+	}
+	slice.Set(merged)
 
-	r.request(endpoint, urlValues, &pointer)
 	return r
 }
 
+// allUnsupported lists the endpoints known to reject `ids=all`, their
+// objects are directly fetched by ids.
+var allUnsupported = map[string]bool{
+	"/achievements":      true,
+	"/commerce/listings": true,
+	"/commerce/prices":   true,
+}
+
+// endpointSet is a set of endpoints safe for concurrent use, shared by the
+// Requestors derived from the same NewRequestor.
+type endpointSet struct {
+	mu        sync.Mutex
+	endpoints map[string]bool
+}
+
+func newEndpointSet() *endpointSet {
+	return &endpointSet{endpoints: make(map[string]bool)}
+}
+
+func (s *endpointSet) has(endpoint string) bool {
+	if s == nil {
+		return false
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.endpoints[endpoint]
+}
+
+func (s *endpointSet) add(endpoint string) {
+	if s == nil {
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.endpoints[endpoint] = true
+}
+
+// This resource returns a list of the dungeons
+// Return the list of all response objects, with `ids=all` when the endpoint
+// supports it. Otherwise, all the ids are fetched then requested by chunks.
+// An endpoint answering `ids=all` with a 400 Bad Request is recorded as not
+// supporting it; the other errors are returned as is.
+func (r *Requestor) collectionAll(endpoint string, pointer interface{}) *Requestor {
+	if r.err != nil {
+		return r
+	}
+
+	if !allUnsupported[endpoint] && !r.rejectsAll.has(endpoint) {
+		all := r.request(endpoint, url.Values{"ids": []string{"all"}}, &pointer)
+
+		var apiErr *APIError
+		if !errors.As(all.err, &apiErr) || apiErr.StatusCode != http.StatusBadRequest {
+			return all
+		}
+		r.rejectsAll.add(endpoint)
+	}
+
+	var ids []json.RawMessage
+	if listed := r.request(endpoint, nil, &ids); listed.err != nil || len(ids) == 0 {
+		return listed
+	}
+
+	return r.collection(endpoint, pointer, rawIDsToStrings(ids))
+}
+
 // This resource returns a list of the dungeons
 // Return an object
 func (r *Requestor) singleton(endpoint string, pointer interface{}, id interface{}) *Requestor {
-	r.request(endpoint, url.Values{"id": []string{fmt.Sprint(id)}}, &pointer)
-	return r
+	return r.request(endpoint, url.Values{"id": []string{fmt.Sprint(id)}}, &pointer)
+}
+
+// idsToStrings flattens the given ids, and slices of ids, into strings.
+func idsToStrings(ids ...interface{}) []string {
+	var sIds []string
+	for _, id := range ids {
+		v := reflect.ValueOf(id)
+		if v.Kind() == reflect.Slice || v.Kind() == reflect.Array {
+			for i := 0; i < v.Len(); i++ {
+				sIds = append(sIds, fmt.Sprint(v.Index(i).Interface()))
+			}
+			continue
+		}
+		sIds = append(sIds, fmt.Sprint(id))
+	}
+	return sIds
+}
+
+// rawIDsToStrings converts ids decoded as raw JSON, either numbers or
+// strings, into strings.
+func rawIDsToStrings(ids []json.RawMessage) []string {
+	sIds := make([]string, len(ids))
+	for i, id := range ids {
+		if err := json.Unmarshal(id, &sIds[i]); err != nil {
+			sIds[i] = string(id)
+		}
+	}
+	return sIds
+}
+
+// chunkIDs splits the ids in chunks of at most `size` ids.
+func chunkIDs(ids []string, size int) [][]string {
+	chunks := make([][]string, 0, (len(ids)+size-1)/size)
+	for size < len(ids) {
+		ids, chunks = ids[size:], append(chunks, ids[:size])
+	}
+	return append(chunks, ids)
+}
+
+// sliceOf dereferences the pointer until the settable slice it points to.
+func sliceOf(pointer interface{}) reflect.Value {
+	v := reflect.ValueOf(pointer)
+	for v.Kind() == reflect.Ptr || v.Kind() == reflect.Interface {
+		if v.IsNil() {
+			return reflect.Value{}
+		}
+		v = v.Elem()
+	}
+	if v.Kind() != reflect.Slice || !v.CanSet() {
+		return reflect.Value{}
+	}
+	return v
 }
@@ -0,0 +1,227 @@
+package gw2api_test
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+// idsTransport answers to collection requests with an object for each
+// requested id, the first chunks being the slowest.
+func idsTransport(t *testing.T, mu *sync.Mutex, chunks *[]int) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		ids := strings.Split(req.URL.Query().Get("ids"), ",")
+		if len(ids) > gw2api.MaxCollectionIDs {
+			t.Errorf("request with %d ids, want at most %d", len(ids), gw2api.MaxCollectionIDs)
+		}
+
+		mu.Lock()
+		*chunks = append(*chunks, len(ids))
+		mu.Unlock()
+
+		if ids[0] == "0" {
+			time.Sleep(20 * time.Millisecond)
+		}
+
+		objects := make([]string, len(ids))
+		for i, id := range ids {
+			objects[i] = fmt.Sprintf(`{"id":%s}`, id)
+		}
+		return jsonResponse(req, http.StatusOK, "["+strings.Join(objects, ",")+"]"), nil
+	}
+}
+
+func TestRequestor_CollectionChunks(t *testing.T) {
+	tests := []struct {
+		name       string
+		ids        int
+		wantChunks int
+	}{
+		{"single request", 200, 1},
+		{"chunked request", 450, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var mu sync.Mutex
+			var chunks []int
+
+			ids := make([]int, tt.ids)
+			for i := range ids {
+				ids[i] = i
+			}
+
+			var prices []*gw2api.CommercePrices
+			err := gw2api.NewRequestor().
+				Transport(idsTransport(t, &mu, &chunks)).
+				ChunkConcurrency(2).
+				CommercePrices(&prices, ids...).
+				Err()
+			if err != nil {
+				t.Fatalf("Requestor.CommercePrices() = %v, want no error", err)
+			}
+
+			if len(chunks) != tt.wantChunks {
+				t.Errorf("Requestor.CommercePrices() sent %d requests, want %d", len(chunks), tt.wantChunks)
+			}
+			if len(prices) != tt.ids {
+				t.Fatalf("Requestor.CommercePrices() returned %d prices, want %d", len(prices), tt.ids)
+			}
+			for i, price := range prices {
+				if price.ID != i {
+					t.Fatalf("Requestor.CommercePrices()[%d].ID = %d, want %d", i, price.ID, i)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestor_CollectionAll(t *testing.T) {
+	// Each test calls AllTitles twice with the same Requestor.
+	tests := []struct {
+		name         string
+		allStatus    int
+		wantErr      error
+		wantRequests []string
+	}{
+		{"endpoint supporting ids=all", http.StatusOK, nil, []string{"ids=all", "ids=all"}},
+		{"endpoint rejecting ids=all", http.StatusBadRequest, nil, []string{"ids=all", "", "ids=1%2C2%2C3", "", "ids=1%2C2%2C3"}},
+		{"rate limited", http.StatusTooManyRequests, gw2api.ErrTooManyRequest, []string{"ids=all", "ids=all"}},
+		{"server error", http.StatusInternalServerError, gw2api.ErrServerError, []string{"ids=all", "ids=all"}},
+		{"unavailable", http.StatusServiceUnavailable, gw2api.ErrUnavailable, []string{"ids=all", "ids=all"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var requests []string
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				requests = append(requests, req.URL.RawQuery)
+				switch req.URL.Query().Get("ids") {
+				case "":
+					return jsonResponse(req, http.StatusOK, `[1,2,3]`), nil
+				case "all":
+					if tt.allStatus != http.StatusOK {
+						return jsonResponse(req, tt.allStatus, `{"text":"all ids not supported"}`), nil
+					}
+				}
+				return jsonResponse(req, http.StatusOK, `[{"id":1},{"id":2},{"id":3}]`), nil
+			})
+
+			r := gw2api.NewRequestor().Transport(transport)
+			for i := 0; i < 2; i++ {
+				var titles []*gw2api.Title
+				err := r.AllTitles(&titles).Err()
+				if tt.wantErr != nil {
+					if !errors.Is(err, tt.wantErr) {
+						t.Errorf("Requestor.AllTitles() = %v, want %v", err, tt.wantErr)
+					}
+					continue
+				}
+				if err != nil {
+					t.Fatalf("Requestor.AllTitles() = %v, want no error", err)
+				}
+				if len(titles) != 3 {
+					t.Errorf("Requestor.AllTitles() returned %d titles, want 3", len(titles))
+				}
+			}
+			if strings.Join(requests, " ") != strings.Join(tt.wantRequests, " ") {
+				t.Errorf("Requestor.AllTitles() requests = %q, want %q", requests, tt.wantRequests)
+			}
+		})
+	}
+}
+
+func TestRequestor_CollectionNotFound(t *testing.T) {
+	// Negative ids are invalid, a request of invalid ids only is answered
+	// with a 404.
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var objects []string
+		for _, id := range strings.Split(req.URL.Query().Get("ids"), ",") {
+			if !strings.HasPrefix(id, "-") {
+				objects = append(objects, fmt.Sprintf(`{"id":%s}`, id))
+			}
+		}
+		if len(objects) == 0 {
+			return jsonResponse(req, http.StatusNotFound, `{"text":"all ids provided are invalid"}`), nil
+		}
+		return jsonResponse(req, http.StatusPartialContent, "["+strings.Join(objects, ",")+"]"), nil
+	})
+	invalid := func(n int) []int {
+		ids := make([]int, n)
+		for i := range ids {
+			ids[i] = -i - 1
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		ids         []int
+		wantPartial bool
+	}{
+		{"single request", invalid(3), false},
+		{"chunked request", invalid(250), false},
+		{"chunked request with a valid chunk", append(make([]int, 200), invalid(50)...), true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var titles []*gw2api.Title
+			err := gw2api.NewRequestor().Transport(transport).Titles(&titles, tt.ids...).Err()
+
+			var partialErr *gw2api.PartialResultError
+			var apiErr *gw2api.APIError
+			switch {
+			case tt.wantPartial:
+				if !errors.As(err, &partialErr) || len(partialErr.Missing) != 50 {
+					t.Errorf("Requestor.Titles() = %v, want a PartialResultError missing 50 ids", err)
+				}
+			case !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || !errors.Is(err, gw2api.ErrNotFound):
+				t.Errorf("Requestor.Titles() = %v, want a 404 APIError", err)
+			}
+		})
+	}
+}
+
+func TestRequestor_CollectionPartialContent(t *testing.T) {
+	// Negative ids are invalid and missing from the responses.
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		var objects []string
+		for _, id := range strings.Split(req.URL.Query().Get("ids"), ",") {
+			if !strings.HasPrefix(id, "-") {
+				objects = append(objects, fmt.Sprintf(`{"id":%s}`, id))
+			}
+		}
+		return jsonResponse(req, http.StatusPartialContent, "["+strings.Join(objects, ",")+"]"), nil
+	})
+
+	tests := []struct {
+		name        string
+		ids         []int
+		wantTitles  int
+		wantMissing []string
+	}{
+		{"single request", []int{1, -2, 3}, 2, []string{"-2"}},
+		{"chunked request", append(make([]int, 250), -1, -2), 250, []string{"-1", "-2"}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var titles []*gw2api.Title
+			err := gw2api.NewRequestor().Transport(transport).Titles(&titles, tt.ids...).Err()
+
+			var partialErr *gw2api.PartialResultError
+			if !errors.As(err, &partialErr) {
+				t.Fatalf("Requestor.Titles() = %v, want a PartialResultError", err)
+			}
+			if strings.Join(partialErr.Missing, ",") != strings.Join(tt.wantMissing, ",") {
+				t.Errorf("PartialResultError.Missing = %v, want %v", partialErr.Missing, tt.wantMissing)
+			}
+			if len(titles) != tt.wantTitles {
+				t.Errorf("Requestor.Titles() returned %d titles, want %d", len(titles), tt.wantTitles)
+			}
+		})
+	}
+}
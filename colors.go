@@ -1,8 +1,6 @@
 //go:generate easytags $GOFILE
 package gw2api
 
-import "net/url"
-
 type Color struct {
 	// The color id.
 	ID int `json:"id"`
@@ -45,20 +43,35 @@ type ColorStruct struct {
 	RGB []int `json:"rgb"`
 }
 
-// This resource returns information about the Biography answers that are in the game.
-func (r *Requestor) Color(color *Color, id string) *Requestor {
-	r.request("/backstory/answers", url.Values{"id": []string{id}}, &color)
-	return r
+// ColorsEndpoint returns the Endpoint of /colors.
+func ColorsEndpoint(r *Requestor) Endpoint[int, Color] {
+	return NewEndpoint[int, Color](r, "/colors")
+}
+
+// This resource returns all dye colors in the game, including localized
+// names and their color component information.
+// Return an object
+func (r *Requestor) Color(color *Color, id int) *Requestor {
+	return ColorsEndpoint(r).get(color, id)
+}
+
+// This resource returns all dye colors in the game, including localized
+// names and their color component information.
+// Return a list of response objects
+func (r *Requestor) Colors(colors *[]*Color, ids ...int) *Requestor {
+	return ColorsEndpoint(r).getMany(colors, ids)
 }
 
-// This resource returns information about the Biography answers that are in the game.
-func (r *Requestor) Colors(colors *[]*Color, ids ...string) *Requestor {
-	r.request("/backstory/answers", url.Values{"id": ids}, &colors)
-	return r
+// This resource returns all dye colors in the game, including localized
+// names and their color component information.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllColors(colors *[]*Color) *Requestor {
+	return ColorsEndpoint(r).all(colors)
 }
 
-// This resource returns information about the Biography answers that are in the game.
-func (r *Requestor) ColorsIDs(s []string) *Requestor {
-	r.request("/backstory/answers", nil, &s)
-	return r
+// This resource returns all dye colors in the game, including localized
+// names and their color component information.
+// Return an array of ids for each color.
+func (r *Requestor) ColorsIDs(s *[]int) *Requestor {
+	return ColorsEndpoint(r).ids(s)
 }
@@ -50,10 +50,10 @@ type CommerceListingsItem struct {
 type CommercePrices struct {
 	// The item id.
 	ID int `json:"id"`
-	// A list of all buy listings, ascending from lowest buy order.
-	Buys []CommercePricesItem `json:"buys"`
-	// A list of all sell listings, ascending from lowest sell offer.
-	Sells []CommercePricesItem `json:"sells"`
+	// The highest buy order.
+	Buys CommercePricesItem `json:"buys"`
+	// The lowest sell offer.
+	Sells CommercePricesItem `json:"sells"`
 	// Indicates whether or not a free to play account can purchase or sell this item on the trading post.
 	Whitelisted bool `json:"whitelisted"`
 }
@@ -81,72 +81,118 @@ type CommerceTransaction struct {
 	Purchased time.Time `json:"purchased"`
 }
 
+// CommerceDeliveryResource returns the Resource of /commerce/delivery.
+func CommerceDeliveryResource(r *Requestor) Resource[CommerceDelivery] {
+	return NewResource[CommerceDelivery](r, "/commerce/delivery")
+}
+
 // This resource returns a list of accepted resources for the gem exchange.
 func (r *Requestor) CommerceDelivery(pointer *CommerceDelivery) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionTradingpost).
-		request("/commerce/delivery", nil, &pointer)
-	return r
+	return CommerceDeliveryResource(r).get(pointer)
+}
+
+// CommerceExchangeCoinsResource returns the Resource of /commerce/exchange/coins.
+func CommerceExchangeCoinsResource(r *Requestor, amount int) Resource[CommerceExchange] {
+	return NewResource[CommerceExchange](r, "/commerce/exchange/coins").
+		withQuery(url.Values{"quantity": []string{fmt.Sprint(amount)}})
 }
 
 // This resource returns the current coins to gems exchange rate.
 // The amount of coins to exchange for gems.
 func (r *Requestor) CommerceExchangeCoins(pointer *CommerceExchange, amount int) *Requestor {
-	r.request("/commerce/exchange/coins", url.Values{"quantity": []string{fmt.Sprint(amount)}}, &pointer)
-	return r
+	return CommerceExchangeCoinsResource(r, amount).get(pointer)
+}
+
+// CommerceExchangeGemsResource returns the Resource of /commerce/exchange/gems.
+func CommerceExchangeGemsResource(r *Requestor, amount int) Resource[CommerceExchange] {
+	return NewResource[CommerceExchange](r, "/commerce/exchange/gems").
+		withQuery(url.Values{"quantity": []string{fmt.Sprint(amount)}})
 }
 
 // This resource returns the current gem to coins exchange rate.
 // The amount of coins to exchange for gems.
 func (r *Requestor) CommerceExchangeGems(pointer *CommerceExchange, amount int) *Requestor {
-	r.request("/commerce/exchange/gems", url.Values{"quantity": []string{fmt.Sprint(amount)}}, &pointer)
-	return r
+	return CommerceExchangeGemsResource(r, amount).get(pointer)
+}
+
+// CommerceListingsEndpoint returns the Endpoint of /commerce/listings.
+func CommerceListingsEndpoint(r *Requestor) Endpoint[int, CommerceListings] {
+	return NewEndpoint[int, CommerceListings](r, "/commerce/listings")
 }
 
 // This resource returns current buy and sell listings from the trading post.
 // Return a list of IDs
 func (r *Requestor) CommerceListingsIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/commerce/listings", &pointer)
-	return r
+	return CommerceListingsEndpoint(r).ids(pointer)
 }
 
 // This resource returns current buy and sell listings from the trading post.
 // Return an array of given Listings
 func (r *Requestor) CommerceListings(pointer *[]*CommerceListings, ids ...int) *Requestor {
-	r.collection("/commerce/listings", &pointer, ids)
-	return r
+	return CommerceListingsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns current buy and sell listings from the trading post.
+// Return a Paginator over the pages of `pageSize` objects.
+func (r *Requestor) CommerceListingsPaginator(pageSize int) *Paginator {
+	return r.paginator("/commerce/listings", pageSize)
+}
+
+// This resource returns current buy and sell listings from the trading post.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllCommerceListings(pointer *[]*CommerceListings) *Requestor {
+	return CommerceListingsEndpoint(r).all(pointer)
 }
 
 // This resource returns current buy and sell listings from the trading post.
 // Return a specific listing
 func (r *Requestor) CommerceListing(pointer *CommerceListings, id int) *Requestor {
-	r.singleton("/commerce/listings", &pointer, id)
-	return r
+	return CommerceListingsEndpoint(r).get(pointer, id)
+}
+
+// CommercePricesEndpoint returns the Endpoint of /commerce/prices.
+func CommercePricesEndpoint(r *Requestor) Endpoint[int, CommercePrices] {
+	return NewEndpoint[int, CommercePrices](r, "/commerce/prices")
 }
 
 // This resource returns current aggregated buy and sell listing
 // information from the trading post.
 // Return a list of IDs
 func (r *Requestor) CommercePricesIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/commerce/prices", &pointer)
-	return r
+	return CommercePricesEndpoint(r).ids(pointer)
 }
 
 // This resource returns current aggregated buy and sell listing
 // information from the trading post.
 // Return an array of given Prices
 func (r *Requestor) CommercePrices(pointer *[]*CommercePrices, ids ...int) *Requestor {
-	r.collection("/commerce/prices", &pointer, ids)
+	return CommercePricesEndpoint(r).getMany(pointer, ids)
+}
 
-	return r
+// This resource returns current aggregated buy and sell listing
+// information from the trading post.
+// Return a Paginator over the pages of `pageSize` objects.
+func (r *Requestor) CommercePricesPaginator(pageSize int) *Paginator {
+	return r.paginator("/commerce/prices", pageSize)
+}
+
+// This resource returns current aggregated buy and sell listing
+// information from the trading post.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllCommercePrices(pointer *[]*CommercePrices) *Requestor {
+	return CommercePricesEndpoint(r).all(pointer)
 }
 
 // This resource returns current aggregated buy and sell listing
 // information from the trading post.
 // Return a specific listing
 func (r *Requestor) CommercePrice(pointer *CommercePrices, id int) *Requestor {
-	r.singleton("/commerce/prices", &pointer, id)
-	return r
+	return CommercePricesEndpoint(r).get(pointer, id)
+}
+
+// CommerceTransactionsCurrentBuysResource returns the Resource of /commerce/transactions/current/buys.
+func CommerceTransactionsCurrentBuysResource(r *Requestor) Resource[[]*CommerceTransaction] {
+	return NewResource[[]*CommerceTransaction](r, "/commerce/transactions/current/buys")
 }
 
 // This resource provides access to the current and historical transactions
@@ -155,10 +201,20 @@ func (r *Requestor) CommercePrice(pointer *CommercePrices, id int) *Requestor {
 // @see https://forum-en.gw2archive.eu/forum/community/api/Launching-v2-commerce-transactions/first
 // Currently unfulfilled transactions.
 func (r *Requestor) CommerceTransactionsCurrentBuys(pointer *[]*CommerceTransaction) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionTradingpost).
-		request("/commerce/transactions/current/buys", nil, &pointer)
-	return r
+	return CommerceTransactionsCurrentBuysResource(r).get(pointer)
+}
+
+// This resource provides access to the current and historical transactions
+// of a player. This is an authenticated endpoint.
+// Currently unfulfilled transactions.
+// Return a Paginator over the pages of `pageSize` transactions.
+func (r *Requestor) CommerceTransactionsCurrentBuysPaginator(pageSize int) *Paginator {
+	return r.paginator("/commerce/transactions/current/buys", pageSize)
+}
+
+// CommerceTransactionsCurrentSellsResource returns the Resource of /commerce/transactions/current/sells.
+func CommerceTransactionsCurrentSellsResource(r *Requestor) Resource[[]*CommerceTransaction] {
+	return NewResource[[]*CommerceTransaction](r, "/commerce/transactions/current/sells")
 }
 
 // This resource provides access to the current and historical transactions
@@ -167,10 +223,20 @@ func (r *Requestor) CommerceTransactionsCurrentBuys(pointer *[]*CommerceTransact
 // @see https://forum-en.gw2archive.eu/forum/community/api/Launching-v2-commerce-transactions/first
 // Currently unfulfilled transactions.
 func (r *Requestor) CommerceTransactionsCurrentSells(pointer *[]*CommerceTransaction) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionTradingpost).
-		request("/commerce/transactions/current/sells", nil, &pointer)
-	return r
+	return CommerceTransactionsCurrentSellsResource(r).get(pointer)
+}
+
+// This resource provides access to the current and historical transactions
+// of a player. This is an authenticated endpoint.
+// Currently unfulfilled transactions.
+// Return a Paginator over the pages of `pageSize` transactions.
+func (r *Requestor) CommerceTransactionsCurrentSellsPaginator(pageSize int) *Paginator {
+	return r.paginator("/commerce/transactions/current/sells", pageSize)
+}
+
+// CommerceTransactionsHistoryBuysResource returns the Resource of /commerce/transactions/history/buys.
+func CommerceTransactionsHistoryBuysResource(r *Requestor) Resource[[]*CommerceTransaction] {
+	return NewResource[[]*CommerceTransaction](r, "/commerce/transactions/history/buys")
 }
 
 // This resource provides access to the current and historical transactions
@@ -179,10 +245,20 @@ func (r *Requestor) CommerceTransactionsCurrentSells(pointer *[]*CommerceTransac
 // @see https://forum-en.gw2archive.eu/forum/community/api/Launching-v2-commerce-transactions/first
 // Fulfilled transactions of the past 90 days.
 func (r *Requestor) CommerceTransactionsHistoryBuys(pointer *[]*CommerceTransaction) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionTradingpost).
-		request("/commerce/transactions/history/buys", nil, &pointer)
-	return r
+	return CommerceTransactionsHistoryBuysResource(r).get(pointer)
+}
+
+// This resource provides access to the current and historical transactions
+// of a player. This is an authenticated endpoint.
+// Fulfilled transactions of the past 90 days.
+// Return a Paginator over the pages of `pageSize` transactions.
+func (r *Requestor) CommerceTransactionsHistoryBuysPaginator(pageSize int) *Paginator {
+	return r.paginator("/commerce/transactions/history/buys", pageSize)
+}
+
+// CommerceTransactionsHistorySellsResource returns the Resource of /commerce/transactions/history/sells.
+func CommerceTransactionsHistorySellsResource(r *Requestor) Resource[[]*CommerceTransaction] {
+	return NewResource[[]*CommerceTransaction](r, "/commerce/transactions/history/sells")
 }
 
 // This resource provides access to the current and historical transactions
@@ -191,8 +267,13 @@ func (r *Requestor) CommerceTransactionsHistoryBuys(pointer *[]*CommerceTransact
 // @see https://forum-en.gw2archive.eu/forum/community/api/Launching-v2-commerce-transactions/first
 // Fulfilled transactions of the past 90 days.
 func (r *Requestor) CommerceTransactionsHistorySells(pointer *[]*CommerceTransaction) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionTradingpost).
-		request("/commerce/transactions/history/sells", nil, &pointer)
-	return r
+	return CommerceTransactionsHistorySellsResource(r).get(pointer)
+}
+
+// This resource provides access to the current and historical transactions
+// of a player. This is an authenticated endpoint.
+// Fulfilled transactions of the past 90 days.
+// Return a Paginator over the pages of `pageSize` transactions.
+func (r *Requestor) CommerceTransactionsHistorySellsPaginator(pageSize int) *Paginator {
+	return r.paginator("/commerce/transactions/history/sells", pageSize)
 }
@@ -10,17 +10,23 @@ type Continent struct {
 	Floors        []int  `json:"floors"`
 }
 
+// ContinentsEndpoint returns the Endpoint of /continents.
+func ContinentsEndpoint(r *Requestor) Endpoint[int, Continent] {
+	return NewEndpoint[int, Continent](r, "/continents")
+}
+
 func (r *Requestor) ContinentIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/continents", &pointer)
-	return r
+	return ContinentsEndpoint(r).ids(pointer)
 }
 
 func (r *Requestor) Continents(pointer *[]*Continent, ids ...int) *Requestor {
-	r.collection("/continents", &pointer, ids)
-	return r
+	return ContinentsEndpoint(r).getMany(pointer, ids)
+}
+
+func (r *Requestor) AllContinents(pointer *[]*Continent) *Requestor {
+	return ContinentsEndpoint(r).all(pointer)
 }
 
 func (r *Requestor) Continent(pointer *Continent, id int) *Requestor {
-	r.singleton("/continents", &pointer, id)
-	return r
+	return ContinentsEndpoint(r).get(pointer, id)
 }
@@ -15,26 +15,35 @@ type Currency struct {
 	Order int `json:"order"`
 }
 
+// CurrenciesEndpoint returns the Endpoint of /currencies.
+func CurrenciesEndpoint(r *Requestor) Endpoint[int, Currency] {
+	return NewEndpoint[int, Currency](r, "/currencies")
+}
+
 // This resource returns a list of the currencies contained in the
 // account wallet.
 // Return an array of ids for each type of currency.
 func (r *Requestor) CurrencyIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/currencies", &pointer)
-	return r
+	return CurrenciesEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the currencies contained in the
 // account wallet.
 // Return a list of response objects
 func (r *Requestor) Currencys(pointer *[]*Currency, ids ...int) *Requestor {
-	r.collection("/currencies", &pointer, ids)
-	return r
+	return CurrenciesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the currencies contained in the
+// account wallet.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllCurrencies(pointer *[]*Currency) *Requestor {
+	return CurrenciesEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the currencies contained in the
 // account wallet.
 // Return an object
 func (r *Requestor) Currency(pointer *Currency, id int) *Requestor {
-	r.singleton("/currencies", &pointer, id)
-	return r
+	return CurrenciesEndpoint(r).get(pointer, id)
 }
@@ -1,9 +1,13 @@
 //go:generate easytags $GOFILE
 package gw2api
 
+// DailyCraftingResource returns the Resource of /dailycrafting.
+func DailyCraftingResource(r *Requestor) Resource[[]string] {
+	return NewResource[[]string](r, "/dailycrafting")
+}
+
 // This resource returns information about time-gated recipes that can
 // be crafted in-game.
 func (r *Requestor) DailyCrafting(pointer *[]string) *Requestor {
-	r.request("/dailycrafting", nil, &pointer)
-	return r
+	return DailyCraftingResource(r).get(pointer)
 }
@@ -15,23 +15,31 @@ type DungeonPath struct {
 	Type string `json:"type"`
 }
 
+// DungeonsEndpoint returns the Endpoint of /dungeons.
+func DungeonsEndpoint(r *Requestor) Endpoint[string, Dungeon] {
+	return NewEndpoint[string, Dungeon](r, "/dungeons")
+}
+
 // This resource returns a list of the dungeons
 // Return an array of ids for each type of currency.
 func (r *Requestor) DungeonIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/dungeons", &pointer)
-	return r
+	return DungeonsEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the dungeons
 // Return a list of response objects
 func (r *Requestor) Dungeons(pointer *[]*Dungeon, ids ...string) *Requestor {
-	r.collection("/dungeons", &pointer, ids)
-	return r
+	return DungeonsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the dungeons
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllDungeons(pointer *[]*Dungeon) *Requestor {
+	return DungeonsEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the dungeons
 // Return an object
 func (r *Requestor) Dungeon(pointer *Dungeon, id string) *Requestor {
-	r.singleton("/dungeons", &pointer, id)
-	return r
+	return DungeonsEndpoint(r).get(pointer, id)
 }
@@ -8,58 +8,70 @@ type Emblem struct {
 	Layers []string `json:"layers"`
 }
 
+// EmblemBackgroundsEndpoint returns the Endpoint of /emblem/backgrounds.
+func EmblemBackgroundsEndpoint(r *Requestor) Endpoint[int, Emblem] {
+	return NewEndpoint[int, Emblem](r, "/emblem/backgrounds")
+}
+
 // This resource returns image resources that are needed to render the
 // background of guild emblems.
 // Return an array of ids for each type of currency.
 func (r *Requestor) EmblemBackgroundIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/emblem/backgrounds", &pointer)
-	return r
+	return EmblemBackgroundsEndpoint(r).ids(pointer)
 }
 
 // This resource returns image resources that are needed to render the
 // background of guild emblems.
 // Return a list of response objects
-// This endpoint is limited to 200 ids.
-//
-// Requesting more than 200 ids will return:
-//   {"text":"id list too long; this endpoint is limited to 200 ids at once"}
+// This endpoint is limited to 200 ids, more ids are requested by chunks.
 func (r *Requestor) EmblemBackgrounds(pointer *[]*Emblem, ids ...int) *Requestor {
-	r.collection("/emblem/backgrounds", &pointer, ids)
-	return r
+	return EmblemBackgroundsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns image resources that are needed to render the
+// background of guild emblems.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllEmblemBackgrounds(pointer *[]*Emblem) *Requestor {
+	return EmblemBackgroundsEndpoint(r).all(pointer)
 }
 
 // This resource returns image resources that are needed to render the
 // background of guild emblems.
 // Return an object
 func (r *Requestor) EmblemBackground(pointer *Emblem, id int) *Requestor {
-	r.singleton("/emblem/backgrounds", &pointer, id)
-	return r
+	return EmblemBackgroundsEndpoint(r).get(pointer, id)
+}
+
+// EmblemForegroundsEndpoint returns the Endpoint of /emblem/foregrounds.
+func EmblemForegroundsEndpoint(r *Requestor) Endpoint[int, Emblem] {
+	return NewEndpoint[int, Emblem](r, "/emblem/foregrounds")
 }
 
 // This resource returns image resources that are needed to render the
 // foreground  of guild emblems.
 // Return an array of ids for each type of currency.
 func (r *Requestor) EmblemForegroundIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/emblem/foregrounds", &pointer)
-	return r
+	return EmblemForegroundsEndpoint(r).ids(pointer)
 }
 
 // This resource returns image resources that are needed to render the
 // foreground  of guild emblems.
 // Return a list of response objects
-// This endpoint is limited to 200 ids.
-//
-// Requesting more than 200 ids will return:
-//   {"text":"id list too long; this endpoint is limited to 200 ids at once"}
+// This endpoint is limited to 200 ids, more ids are requested by chunks.
 func (r *Requestor) EmblemForegrounds(pointer *[]*Emblem, ids ...int) *Requestor {
-	r.collection("/emblem/foregrounds", &pointer, ids)
-	return r
+	return EmblemForegroundsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns image resources that are needed to render the
+// foreground  of guild emblems.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllEmblemForegrounds(pointer *[]*Emblem) *Requestor {
+	return EmblemForegroundsEndpoint(r).all(pointer)
 }
 
 // This resource returns image resources that are needed to render the
 // foreground  of guild emblems.
 // Return an object
 func (r *Requestor) EmblemForeground(pointer *Emblem, id int) *Requestor {
-	r.singleton("/emblem/foregrounds", &pointer, id)
-	return r
+	return EmblemForegroundsEndpoint(r).get(pointer, id)
 }
@@ -10,23 +10,31 @@ type Emote struct {
 	UnlockItems []int `json:"unlock_items"`
 }
 
+// EmotesEndpoint returns the Endpoint of /emotes.
+func EmotesEndpoint(r *Requestor) Endpoint[string, Emote] {
+	return NewEndpoint[string, Emote](r, "/emotes")
+}
+
 // This resource returns a list of the emotes
 // Return an array of ids for each type of currency.
 func (r *Requestor) EmoteIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/emotes", &pointer)
-	return r
+	return EmotesEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the emotes
 // Return a list of response objects
 func (r *Requestor) Emotes(pointer *[]*Emote, ids ...string) *Requestor {
-	r.collection("/emotes", &pointer, ids)
-	return r
+	return EmotesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the emotes
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllEmotes(pointer *[]*Emote) *Requestor {
+	return EmotesEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the emotes
 // Return an object
 func (r *Requestor) Emote(pointer *Emote, id string) *Requestor {
-	r.singleton("/emotes", &pointer, id)
-	return r
+	return EmotesEndpoint(r).get(pointer, id)
 }
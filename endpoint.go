@@ -0,0 +1,147 @@
+package gw2api
+
+import (
+	"net/url"
+	"strings"
+)
+
+// ID is the type of the ids identifying the objects of an Endpoint.
+type ID interface {
+	~int | ~string
+}
+
+// Endpoint is a bulk-expanded endpoint of the API, serving objects of type T
+// identified by ids of type K. Its methods return the decoded objects instead
+// of filling a pointer, with the error of the request.
+type Endpoint[K ID, T any] struct {
+	requestor *Requestor
+	path      string
+	perms     []TokenPermission
+}
+
+// NewEndpoint returns the Endpoint at the given path, performing its requests
+// with the Requestor. When permissions are given, the Endpoint requires an
+// API key granting them, else the ones of the path in the ScopeRegistry.
+func NewEndpoint[K ID, T any](r *Requestor, path string, perms ...TokenPermission) Endpoint[K, T] {
+	if len(perms) == 0 {
+		perms = RequiredScopes(path)
+	}
+	return Endpoint[K, T]{requestor: r, path: path, perms: perms}
+}
+
+// Get returns the object with the given id.
+func (e Endpoint[K, T]) Get(id K) (T, error) {
+	var v T
+	err := e.get(&v, id).Err()
+	return v, err
+}
+
+// GetMany returns the objects with the given ids, requested by chunks when
+// more than MaxCollectionIDs ids are given. When some ids are invalid, the
+// valid objects are returned with a *PartialResultError.
+func (e Endpoint[K, T]) GetMany(ids ...K) ([]*T, error) {
+	var v []*T
+	err := e.getMany(&v, ids).Err()
+	return v, err
+}
+
+// IDs returns the ids of all the objects of the Endpoint.
+func (e Endpoint[K, T]) IDs() ([]K, error) {
+	var v []K
+	err := e.ids(&v).Err()
+	return v, err
+}
+
+// All returns all the objects of the Endpoint, using `ids=all` when supported.
+func (e Endpoint[K, T]) All() ([]*T, error) {
+	var v []*T
+	err := e.all(&v).Err()
+	return v, err
+}
+
+func (e Endpoint[K, T]) get(pointer *T, id K) *Requestor {
+	return authorize(e.requestor, e.perms).singleton(e.path, pointer, id)
+}
+
+func (e Endpoint[K, T]) getMany(pointer *[]*T, ids []K) *Requestor {
+	return authorize(e.requestor, e.perms).collection(e.path, pointer, ids)
+}
+
+func (e Endpoint[K, T]) ids(pointer *[]K) *Requestor {
+	return authorize(e.requestor, e.perms).collectionIDs(e.path, pointer)
+}
+
+func (e Endpoint[K, T]) all(pointer *[]*T) *Requestor {
+	return authorize(e.requestor, e.perms).collectionAll(e.path, pointer)
+}
+
+// Resource is an endpoint of the API serving a single object of type T, like
+// /account or /build. Its Get method returns the decoded object instead of
+// filling a pointer, with the error of the request.
+type Resource[T any] struct {
+	requestor *Requestor
+	path      string
+	query     url.Values
+	perms     []TokenPermission
+}
+
+// NewResource returns the Resource at the given path, performing its request
+// with the Requestor. When permissions are given, the Resource requires an
+// API key granting them, else the ones of the path in the ScopeRegistry.
+func NewResource[T any](r *Requestor, path string, perms ...TokenPermission) Resource[T] {
+	if len(perms) == 0 {
+		perms = RequiredScopes(path)
+	}
+	return Resource[T]{requestor: r, path: path, perms: perms}
+}
+
+// Get returns the object of the Resource.
+func (res Resource[T]) Get() (T, error) {
+	var v T
+	err := res.get(&v).Err()
+	return v, err
+}
+
+// withQuery returns a copy of the Resource requested with the parameters.
+func (res Resource[T]) withQuery(query url.Values) Resource[T] {
+	res.query = query
+	return res
+}
+
+func (res Resource[T]) get(pointer *T) *Requestor {
+	return authorize(res.requestor, res.perms).request(res.path, res.query, pointer)
+}
+
+// authorize checks the permissions required by an authenticated endpoint,
+// drawing a key granting them from the KeyPool when no key is given to Auth.
+// Endpoints without permissions are public and don't need an API key.
+func authorize(r *Requestor, perms []TokenPermission) *Requestor {
+	if len(perms) == 0 {
+		return r
+	}
+	if r.err == nil && r.token == "" && r.pool != nil {
+		return r.drawKey(perms)
+	}
+	return r.needPerms(perms...)
+}
+
+// staticGuildEndpoints lists the endpoints under /guild which are not a guild id.
+var staticGuildEndpoints = map[string]bool{"permissions": true, "search": true, "upgrades": true}
+
+// endpointTemplate returns the path template of the endpoint, its dynamic
+// segments replaced by placeholders: "/characters/My Character/buildtabs/2"
+// gives "/characters/:name/buildtabs/:tab".
+func endpointTemplate(endpoint string) string {
+	segments := strings.Split(strings.TrimPrefix(endpoint, "/"), "/")
+
+	switch {
+	case len(segments) >= 2 && segments[0] == "characters":
+		segments[1] = ":name"
+		if len(segments) == 4 && segments[3] != "active" {
+			segments[3] = ":tab"
+		}
+	case len(segments) >= 2 && segments[0] == "guild" && !staticGuildEndpoints[segments[1]]:
+		segments[1] = ":id"
+	}
+	return "/" + strings.Join(segments, "/")
+}
@@ -0,0 +1,132 @@
+package gw2api_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestEndpoint(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		query := req.URL.Query()
+		switch {
+		case query.Get("id") != "":
+			return jsonResponse(req, http.StatusOK, `{"id":`+query.Get("id")+`,"name":"Title"}`), nil
+		case query.Get("ids") == "all" || query.Get("ids") == "1,2":
+			return jsonResponse(req, http.StatusOK, `[{"id":1},{"id":2}]`), nil
+		default:
+			return jsonResponse(req, http.StatusOK, `[1,2]`), nil
+		}
+	})
+	titles := gw2api.TitlesEndpoint(gw2api.NewRequestor().Transport(transport))
+
+	tests := []struct {
+		name string
+		call func() (interface{}, error)
+		want interface{}
+	}{
+		{"Get", func() (interface{}, error) { return titles.Get(1) }, gw2api.Title{ID: 1, Name: "Title"}},
+		{"GetMany", func() (interface{}, error) { return titles.GetMany(1, 2) }, []*gw2api.Title{{ID: 1}, {ID: 2}}},
+		{"IDs", func() (interface{}, error) { return titles.IDs() }, []int{1, 2}},
+		{"All", func() (interface{}, error) { return titles.All() }, []*gw2api.Title{{ID: 1}, {ID: 2}}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.call()
+			if err != nil {
+				t.Fatalf("Endpoint.%s() = %v, want no error", tt.name, err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Endpoint.%s() = %+v, want %+v", tt.name, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestResource(t *testing.T) {
+	var paths []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		paths = append(paths, req.URL.Path+"?"+req.URL.RawQuery)
+		return jsonResponse(req, http.StatusOK, `["guild-id"]`), nil
+	})
+	r := gw2api.NewRequestor().Transport(transport)
+
+	ids, err := gw2api.GuildSearchResource(r, "My Guild").Get()
+	if err != nil {
+		t.Fatalf("Resource.Get() = %v, want no error", err)
+	}
+	if !reflect.DeepEqual(ids, []string{"guild-id"}) {
+		t.Errorf("Resource.Get() = %v, want %v", ids, []string{"guild-id"})
+	}
+	if want := []string{"/v2/guild/search?name=My+Guild"}; !reflect.DeepEqual(paths, want) {
+		t.Errorf("requested %v, want %v", paths, want)
+	}
+
+	if _, err := gw2api.AccountResource(r).Get(); !errors.Is(err, gw2api.ErrRequireAuthentication) {
+		t.Errorf("Resource.Get() = %v, want %v", err, gw2api.ErrRequireAuthentication)
+	}
+	if len(paths) != 1 {
+		t.Errorf("authenticated resource requested without API key")
+	}
+}
+
+func TestEndpoint_IntIDs(t *testing.T) {
+	r := newRequestor()
+	tests := []struct {
+		name string
+		ids  func() ([]int, error)
+		all  func() (int, error)
+	}{
+		{
+			name: "guild upgrades",
+			ids:  gw2api.UnscopedGuildUpgradesEndpoint(r).IDs,
+			all: func() (int, error) {
+				upgrades, err := gw2api.UnscopedGuildUpgradesEndpoint(r).All()
+				if err != nil || len(upgrades) == 0 {
+					return 0, err
+				}
+				return upgrades[0].ID, nil
+			},
+		},
+		{
+			name: "wvw ranks",
+			ids:  gw2api.WvwRanksEndpoint(r).IDs,
+			all: func() (int, error) {
+				ranks, err := gw2api.WvwRanksEndpoint(r).All()
+				if err != nil || len(ranks) == 0 {
+					return 0, err
+				}
+				return ranks[0].ID, nil
+			},
+		},
+		{
+			name: "backstory questions",
+			ids:  gw2api.BackstoryQuestionsEndpoint(r).IDs,
+			all: func() (int, error) {
+				questions, err := gw2api.BackstoryQuestionsEndpoint(r).All()
+				if err != nil || len(questions) == 0 {
+					return 0, err
+				}
+				return questions[0].ID, nil
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ids, err := tt.ids()
+			if err != nil || len(ids) == 0 {
+				t.Fatalf("Endpoint.IDs() = %v, %v, want ids", ids, err)
+			}
+			id, err := tt.all()
+			if err != nil {
+				t.Fatalf("Endpoint.All() = %v, want no error", err)
+			}
+			if id != ids[0] {
+				t.Errorf("Endpoint.All() first id = %d, want %d", id, ids[0])
+			}
+		})
+	}
+}
@@ -1,14 +1,204 @@
 package gw2api
 
-import "fmt"
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+)
 
+// ErrorKind classifies the errors returned by the API.
+type ErrorKind int
+
+const (
+	ErrorKindUnknown ErrorKind = iota
+	// The requested id, account or resource does not exist.
+	ErrorKindNotFound
+	// The API key is invalid or revoked.
+	ErrorKindInvalidKey
+	// The API key does not have the scopes required by the endpoint.
+	ErrorKindMissingScope
+	// Too many requests have been sent.
+	ErrorKindRateLimited
+	// The API failed to process the request.
+	ErrorKindServerError
+	// The API or the endpoint is disabled or temporarily unavailable.
+	ErrorKindUnavailable
+)
+
+var (
+	ErrNotFound    = errors.New("not found")
+	ErrInvalidKey  = errors.New("invalid API key")
+	ErrServerError = errors.New("API server error")
+	ErrUnavailable = errors.New("API unavailable")
+)
+
+func (k ErrorKind) String() string {
+	switch k {
+	case ErrorKindNotFound:
+		return "not found"
+	case ErrorKindInvalidKey:
+		return "invalid key"
+	case ErrorKindMissingScope:
+		return "missing scope"
+	case ErrorKindRateLimited:
+		return "rate limited"
+	case ErrorKindServerError:
+		return "server error"
+	case ErrorKindUnavailable:
+		return "unavailable"
+	}
+	return "unknown"
+}
+
+// sentinel returns the error matching the kind with errors.Is.
+func (k ErrorKind) sentinel() error {
+	switch k {
+	case ErrorKindNotFound:
+		return ErrNotFound
+	case ErrorKindInvalidKey:
+		return ErrInvalidKey
+	case ErrorKindMissingScope:
+		return ErrMissingScope
+	case ErrorKindRateLimited:
+		return ErrTooManyRequest
+	case ErrorKindServerError:
+		return ErrServerError
+	case ErrorKindUnavailable:
+		return ErrUnavailable
+	}
+	return nil
+}
+
+// APIError is an error returned by the API. It matches with errors.Is the
+// sentinel error of its kind: ErrNotFound, ErrInvalidKey, ErrMissingScope,
+// ErrTooManyRequest, ErrServerError or ErrUnavailable.
 type APIError struct {
-	Err  string `json:"error"`
-	Text string `json:"text"`
+	// The HTTP status of the response.
+	StatusCode int
+	// The endpoint requested.
+	Endpoint string
+	// The id of the request, from the X-Request-Id header.
+	RequestID string
+	// The error text sent by the API.
+	Text string
+	// The classification of the error.
+	Kind ErrorKind
+}
+
+func (e *APIError) Error() string {
+	return fmt.Sprintf("API Error: %s: %d %s: %s", e.Endpoint, e.StatusCode, e.Kind, e.Text)
 }
 
-func (e APIError) Error() string {
-	e.Err += e.Text
+// Is reports whether the target is the sentinel error of the kind of the
+// error.
+func (e *APIError) Is(target error) bool {
+	return target != nil && target == e.Kind.sentinel()
+}
+
+// newAPIError returns the APIError of a response with an error status.
+// The body is expected to be a JSON object with a `text` field, any other
+// body is used as the text of the error.
+func newAPIError(endpoint string, response *http.Response) *APIError {
+	apiErr := &APIError{
+		StatusCode: response.StatusCode,
+		Endpoint:   endpoint,
+		RequestID:  response.Header.Get("X-Request-Id"),
+	}
+
+	body, _ := io.ReadAll(io.LimitReader(response.Body, 4096))
+	var payload struct {
+		Text  string `json:"text"`
+		Error string `json:"error"`
+	}
+	if err := json.Unmarshal(body, &payload); err == nil {
+		apiErr.Text = payload.Text
+		if apiErr.Text == "" {
+			apiErr.Text = payload.Error
+		}
+	} else {
+		apiErr.Text = strings.TrimSpace(string(body))
+	}
+
+	apiErr.Kind = errorKind(response.StatusCode, apiErr.Text)
+	return apiErr
+}
+
+// errorKind classifies an error from its HTTP status and text.
+func errorKind(status int, text string) ErrorKind {
+	text = strings.ToLower(text)
+	switch {
+	case status == http.StatusTooManyRequests:
+		return ErrorKindRateLimited
+	case strings.Contains(text, "invalid key"),
+		strings.Contains(text, "invalid access token"):
+		return ErrorKindInvalidKey
+	case strings.Contains(text, "requires scope"),
+		strings.Contains(text, "requires permission"):
+		return ErrorKindMissingScope
+	case strings.Contains(text, "disabled"),
+		strings.Contains(text, "not active"),
+		status == http.StatusBadGateway,
+		status == http.StatusServiceUnavailable,
+		status == http.StatusGatewayTimeout:
+		return ErrorKindUnavailable
+	case status == http.StatusUnauthorized:
+		return ErrorKindInvalidKey
+	case status == http.StatusForbidden:
+		return ErrorKindMissingScope
+	case status == http.StatusNotFound,
+		strings.Contains(text, "no such id"),
+		strings.Contains(text, "not found"),
+		strings.Contains(text, "ids provided are invalid"):
+		return ErrorKindNotFound
+	case status >= http.StatusInternalServerError:
+		return ErrorKindServerError
+	}
+	return ErrorKindUnknown
+}
+
+// PartialResultError is returned when the API answers with a 206 Partial
+// Content: some of the requested ids are invalid. The objects of the valid
+// ids are still decoded, the error can be ignored to use them.
+type PartialResultError struct {
+	// The endpoint requested.
+	Endpoint string
+	// The requested ids missing from the response.
+	Missing []string
+}
+
+func (e *PartialResultError) Error() string {
+	return fmt.Sprintf("partial result from %s: ids not found: %s", e.Endpoint, strings.Join(e.Missing, ","))
+}
+
+// partialResultErr returns the PartialResultError listing the ids requested
+// in the query missing from the body of a 206 Partial Content response.
+func partialResultErr(endpoint string, query url.Values, body []byte) error {
+	var objects []struct {
+		ID json.RawMessage `json:"id"`
+	}
+	if err := json.Unmarshal(body, &objects); err != nil {
+		return err
+	}
+
+	ids := make([]json.RawMessage, len(objects))
+	for i, object := range objects {
+		ids[i] = object.ID
+	}
+
+	returned := make(map[string]bool, len(ids))
+	for _, id := range rawIDsToStrings(ids) {
+		returned[id] = true
+	}
 
-	return fmt.Sprintf("API Error: %s", e.Err)
+	partialErr := &PartialResultError{Endpoint: endpoint}
+	for _, id := range strings.Split(query.Get("ids"), ",") {
+		if !returned[id] {
+			partialErr.Missing = append(partialErr.Missing, id)
+		}
+	}
+	return partialErr
 }
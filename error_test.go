@@ -0,0 +1,54 @@
+package gw2api_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestAPIError(t *testing.T) {
+	tests := []struct {
+		name     string
+		status   int
+		body     string
+		wantKind gw2api.ErrorKind
+		wantIs   error
+	}{
+		{"no such id", http.StatusNotFound, `{"text":"no such id"}`, gw2api.ErrorKindNotFound, gw2api.ErrNotFound},
+		{"invalid key", http.StatusUnauthorized, `{"text":"Invalid access token"}`, gw2api.ErrorKindInvalidKey, gw2api.ErrInvalidKey},
+		{"missing scope", http.StatusForbidden, `{"text":"requires scope characters"}`, gw2api.ErrorKindMissingScope, gw2api.ErrMissingScope},
+		{"rate limited", http.StatusTooManyRequests, `{"text":"too many requests"}`, gw2api.ErrorKindRateLimited, gw2api.ErrTooManyRequest},
+		{"server error", http.StatusInternalServerError, `{"text":"internal error"}`, gw2api.ErrorKindServerError, gw2api.ErrServerError},
+		{"endpoint disabled", http.StatusNotFound, `{"text":"API not active"}`, gw2api.ErrorKindUnavailable, gw2api.ErrUnavailable},
+		{"gateway error", http.StatusBadGateway, `<html>Bad Gateway</html>`, gw2api.ErrorKindUnavailable, gw2api.ErrUnavailable},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				response := jsonResponse(req, tt.status, tt.body)
+				response.Header.Set("X-Request-Id", "request-id")
+				return response, nil
+			})
+
+			var world gw2api.World
+			err := gw2api.NewRequestor().Transport(transport).World(&world, 1).Err()
+
+			var apiErr *gw2api.APIError
+			if !errors.As(err, &apiErr) {
+				t.Fatalf("Requestor.World() = %v, want an APIError", err)
+			}
+			if apiErr.Kind != tt.wantKind || apiErr.StatusCode != tt.status ||
+				apiErr.Endpoint != "/worlds" || apiErr.RequestID != "request-id" {
+				t.Errorf("Requestor.World() = %+v, want kind %s and status %d", apiErr, tt.wantKind, tt.status)
+			}
+			if !errors.Is(err, tt.wantIs) {
+				t.Errorf("errors.Is(%v, %v) = false, want true", err, tt.wantIs)
+			}
+			if errors.Is(err, gw2api.ErrRequireAuthentication) {
+				t.Errorf("errors.Is(%v, %v) = true, want false", err, gw2api.ErrRequireAuthentication)
+			}
+		})
+	}
+}
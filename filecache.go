@@ -0,0 +1,83 @@
+package gw2api
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"os"
+	"path/filepath"
+	"time"
+)
+
+// FileCache is a Cache storing the responses on the filesystem, one file per
+// entry, so they survive the restarts of the process. It is safe for
+// concurrent use, including by several processes sharing the directory.
+type FileCache struct {
+	dir string
+}
+
+type fileCacheEntry struct {
+	CacheEntry
+	Deadline time.Time `json:"deadline"`
+}
+
+// NewFileCache returns a FileCache storing its entries in the given
+// directory, created if needed.
+func NewFileCache(dir string) (*FileCache, error) {
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, err
+	}
+	return &FileCache{dir: dir}, nil
+}
+
+func (c *FileCache) Get(key string) (CacheEntry, bool) {
+	data, err := os.ReadFile(c.path(key))
+	if err != nil {
+		return CacheEntry{}, false
+	}
+
+	var entry fileCacheEntry
+	if err := json.Unmarshal(data, &entry); err != nil {
+		c.Delete(key)
+		return CacheEntry{}, false
+	}
+	if time.Now().After(entry.Deadline) {
+		c.Delete(key)
+		return CacheEntry{}, false
+	}
+	return entry.CacheEntry, true
+}
+
+func (c *FileCache) Set(key string, entry CacheEntry, ttl time.Duration) {
+	data, err := json.Marshal(fileCacheEntry{CacheEntry: entry, Deadline: time.Now().Add(ttl)})
+	if err != nil {
+		return
+	}
+
+	// Write in a temporary file then rename it, so a concurrent Get never
+	// reads a partially written entry.
+	tmp, err := os.CreateTemp(c.dir, ".tmp-*")
+	if err != nil {
+		return
+	}
+	defer os.Remove(tmp.Name())
+
+	if _, err = tmp.Write(data); err != nil {
+		tmp.Close()
+		return
+	}
+	if err = tmp.Close(); err != nil {
+		return
+	}
+	_ = os.Rename(tmp.Name(), c.path(key))
+}
+
+func (c *FileCache) Delete(key string) {
+	_ = os.Remove(c.path(key))
+}
+
+// path returns the file storing the entry of the key.
+func (c *FileCache) path(key string) string {
+	sum := sha256.Sum256([]byte(key))
+	return filepath.Join(c.dir, hex.EncodeToString(sum[:])+".json")
+}
@@ -8,23 +8,31 @@ type File struct {
 	Icon string `json:"icon"`
 }
 
+// FilesEndpoint returns the Endpoint of /files.
+func FilesEndpoint(r *Requestor) Endpoint[string, File] {
+	return NewEndpoint[string, File](r, "/files")
+}
+
 // This resource returns a list of the files
 // Return an array of ids for each type of currency.
 func (r *Requestor) FileIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/files", &pointer)
-	return r
+	return FilesEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the files
 // Return a list of response objects
 func (r *Requestor) Files(pointer *[]*File, ids ...string) *Requestor {
-	r.collection("/files", &pointer, ids)
-	return r
+	return FilesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the files
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllFiles(pointer *[]*File) *Requestor {
+	return FilesEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the files
 // Return an object
 func (r *Requestor) File(pointer *File, id string) *Requestor {
-	r.singleton("/files", &pointer, id)
-	return r
+	return FilesEndpoint(r).get(pointer, id)
 }
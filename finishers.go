@@ -17,23 +17,31 @@ type Finisher struct {
 	Name string `json:"name"`
 }
 
+// FinishersEndpoint returns the Endpoint of /finishers.
+func FinishersEndpoint(r *Requestor) Endpoint[int, Finisher] {
+	return NewEndpoint[int, Finisher](r, "/finishers")
+}
+
 // This resource returns a list of the finishers
 // Return an array of ids for each type of currency.
 func (r *Requestor) FinisherIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/finishers", &pointer)
-	return r
+	return FinishersEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the finishers
 // Return a list of response objects
 func (r *Requestor) Finishers(pointer *[]*Finisher, ids ...int) *Requestor {
-	r.collection("/finishers", &pointer, ids)
-	return r
+	return FinishersEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the finishers
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllFinishers(pointer *[]*Finisher) *Requestor {
+	return FinishersEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the finishers
 // Return an object
 func (r *Requestor) Finisher(pointer *Finisher, id int) *Requestor {
-	r.singleton("/finishers", &pointer, id)
-	return r
+	return FinishersEndpoint(r).get(pointer, id)
 }
@@ -20,23 +20,31 @@ type Glider struct {
 	DefaultDyes []int `json:"default_dyes"`
 }
 
+// GlidersEndpoint returns the Endpoint of /gliders.
+func GlidersEndpoint(r *Requestor) Endpoint[int, Glider] {
+	return NewEndpoint[int, Glider](r, "/gliders")
+}
+
 // This resource returns a list of the gliders
 // Return an array of ids for each type of currency.
 func (r *Requestor) GliderIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/gliders", &pointer)
-	return r
+	return GlidersEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the gliders
 // Return a list of response objects
 func (r *Requestor) Gliders(pointer *[]*Glider, ids ...int) *Requestor {
-	r.collection("/gliders", &pointer, ids)
-	return r
+	return GlidersEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the gliders
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllGliders(pointer *[]*Glider) *Requestor {
+	return GlidersEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the gliders
 // Return an object
 func (r *Requestor) Glider(pointer *Glider, id int) *Requestor {
-	r.singleton("/gliders", &pointer, id)
-	return r
+	return GlidersEndpoint(r).get(pointer, id)
 }
@@ -1,3 +1,3 @@
 module atomys.codes/gw2api-go
 
-go 1.16
+go 1.21
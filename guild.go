@@ -208,7 +208,7 @@ type GuildPermission struct {
 
 type GuildUpgrade struct {
 	// The upgrade id.
-	ID string `json:"id"`
+	ID int `json:"id"`
 	// The name of the upgrade.
 	Name string `json:"name"`
 	// The guild upgrade description.
@@ -264,13 +264,28 @@ type GuildUpgradeCost struct {
 	ItemID int `json:"item_id"`
 }
 
+// GuildResource returns the Resource of /guild/:id.
+func GuildResource(r *Requestor, id string) Resource[Guild] {
+	return NewResource[Guild](r, fmt.Sprintf("/guild/%s", id))
+}
+
 // This resource returns core details about a given guild. The end point will
 // include more or less fields dependend on whether or not an API Key of a
 // Leader or Member of the Guild with the guilds scope is included
 // in the Request.
 func (r *Requestor) Guild(pointer *Guild, id string) *Requestor {
-	r.request(fmt.Sprintf("/guild/%s", id), nil, &pointer)
-	return r
+	return GuildResource(r, id).get(pointer)
+}
+
+// GuildLogsResource returns the Resource of /guild/:guildID/log.
+func GuildLogsResource(r *Requestor, guildID string, sinceID string) Resource[[]*GuildLog] {
+	urlValues := url.Values{}
+	if sinceID != "" {
+		urlValues["since"] = []string{sinceID}
+	}
+
+	return NewResource[[]*GuildLog](r, fmt.Sprintf("/guild/%s/log", guildID)).
+		withQuery(urlValues)
 }
 
 // This resource returns information about certain events in a guild's log.
@@ -285,97 +300,114 @@ func (r *Requestor) Guild(pointer *Guild, id string) *Requestor {
 //                     than since will be omitted.
 //                     (set since to empty string to avoid)
 func (r *Requestor) GuildLogs(pointer *[]*GuildLog, guildID string, sinceID string) *Requestor {
-	urlValues := url.Values{}
-	if sinceID != "" {
-		urlValues["since"] = []string{sinceID}
-	}
-
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/log", guildID), urlValues, &pointer)
+	return GuildLogsResource(r, guildID, sinceID).get(pointer)
+}
 
-	return r
+// GuildMembersResource returns the Resource of /guild/:guildID/members.
+func GuildMembersResource(r *Requestor, guildID string) Resource[[]*GuildMember] {
+	return NewResource[[]*GuildMember](r, fmt.Sprintf("/guild/%s/members", guildID))
 }
 
 // This resource returns information about the members of a specified guild.
 // The endpoint requires the scope guilds, and will only work if the API key
 // is from the guild leader's account.
 func (r *Requestor) GuildMembers(pointer *[]*GuildMember, guildID string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/members", guildID), nil, &pointer)
-	return r
+	return GuildMembersResource(r, guildID).get(pointer)
+}
+
+// GuildRanksResource returns the Resource of /guild/:guildID/ranks.
+func GuildRanksResource(r *Requestor, guildID string) Resource[[]*GuildRank] {
+	return NewResource[[]*GuildRank](r, fmt.Sprintf("/guild/%s/ranks", guildID))
 }
 
 // This resource returns information about the ranks of a specified guild.
 // The endpoint requires the scope guilds, and will only work if the API key
 // is from the guild leader's account.
 func (r *Requestor) GuildRanks(pointer *[]*GuildRank, guildID string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/ranks", guildID), nil, &pointer)
-	return r
+	return GuildRanksResource(r, guildID).get(pointer)
+}
+
+// GuildStashResource returns the Resource of /guild/:guildID/stash.
+func GuildStashResource(r *Requestor, guildID string) Resource[[]*GuildStash] {
+	return NewResource[[]*GuildStash](r, fmt.Sprintf("/guild/%s/stash", guildID))
 }
 
 // This resource returns information about the items in a guild's vault.
 // The endpoint requires the scope guilds, and will only work if the API key
 // is from the guild leader's account.
 func (r *Requestor) GuildStash(pointer *[]*GuildStash, guildID string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/stash", guildID), nil, &pointer)
-	return r
+	return GuildStashResource(r, guildID).get(pointer)
+}
+
+// GuildStorageResource returns the Resource of /guild/:guildID/storage.
+func GuildStorageResource(r *Requestor, guildID string) Resource[[]*GuildInventoryItem] {
+	return NewResource[[]*GuildInventoryItem](r, fmt.Sprintf("/guild/%s/storage", guildID))
 }
 
 // This resource returns information about the items in a guild's storage.
 // The endpoint requires the scope guilds, and will only work if the API key
 //is from the guild leader's account.
 func (r *Requestor) GuildStorage(pointer *[]*GuildInventoryItem, guildID string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/storage", guildID), nil, &pointer)
-	return r
+	return GuildStorageResource(r, guildID).get(pointer)
+}
+
+// GuildTreasuryResource returns the Resource of /guild/:guildID/treasury.
+func GuildTreasuryResource(r *Requestor, guildID string) Resource[[]*GuildTreasury] {
+	return NewResource[[]*GuildTreasury](r, fmt.Sprintf("/guild/%s/treasury", guildID))
 }
 
 // This resource returns information about the items in a guild's treasury.
 // The endpoint requires the scope guilds, and will only work if the API key
 // is from the guild leader's account.
 func (r *Requestor) GuildTreasury(pointer *[]*GuildTreasury, guildID string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/treasury", guildID), nil, &pointer)
-	return r
+	return GuildTreasuryResource(r, guildID).get(pointer)
+}
+
+// GuildUpgradesResource returns the Resource of /guild/:guildID/upgrades.
+func GuildUpgradesResource(r *Requestor, guildID string) Resource[[]int] {
+	return NewResource[[]int](r, fmt.Sprintf("/guild/%s/upgrades", guildID))
 }
 
 // This resource returns information about the guild's upgrades. The endpoint
 // requires the scope guilds, and will only work if the API key is
 // from the guild leader's account.
 func (r *Requestor) GuildUpgrades(pointer *[]int, guildID string) *Requestor {
-	r.
-		needPerms(TokenPermissionAccount, TokenPermissionGuilds).
-		request(fmt.Sprintf("/guild/%s/upgrades", guildID), nil, &pointer)
-	return r
+	return GuildUpgradesResource(r, guildID).get(pointer)
+}
+
+// GuildPermissionsEndpoint returns the Endpoint of /guild/permissions.
+func GuildPermissionsEndpoint(r *Requestor) Endpoint[string, GuildPermission] {
+	return NewEndpoint[string, GuildPermission](r, "/guild/permissions")
 }
 
 // This resource returns a list of the guild permissions
 // Return an array of ids for each type of currency.
 func (r *Requestor) GuildPermissionIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/guild/permissions", &pointer)
-	return r
+	return GuildPermissionsEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the guild permissions
 // Return a list of response objects
 func (r *Requestor) GuildPermissions(pointer *[]*GuildPermission, ids ...string) *Requestor {
-	r.collection("/guild/permissions", &pointer, ids)
-	return r
+	return GuildPermissionsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the guild permissions
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllGuildPermissions(pointer *[]*GuildPermission) *Requestor {
+	return GuildPermissionsEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the guild permissions
 // Return an object
 func (r *Requestor) GuildPermission(pointer *GuildPermission, id string) *Requestor {
-	r.singleton("/guild/permissions", &pointer, id)
-	return r
+	return GuildPermissionsEndpoint(r).get(pointer, id)
+}
+
+// GuildSearchResource returns the Resource of /guild/search.
+func GuildSearchResource(r *Requestor, name string) Resource[[]string] {
+	return NewResource[[]string](r, "/guild/search").
+		withQuery(url.Values{"name": []string{name}})
 }
 
 // This resource returns information on guild ids to be used for other
@@ -383,27 +415,34 @@ func (r *Requestor) GuildPermission(pointer *GuildPermission, id string) *Reques
 //   @param name - The guild name must be given in order to obtain the
 //                 relevant id.
 func (r *Requestor) GuildSearch(pointer *[]string, name string) *Requestor {
-	r.singleton("/guild/permissions", &pointer, name)
-	return r
+	return GuildSearchResource(r, name).get(pointer)
+}
+
+// UnscopedGuildUpgradesEndpoint returns the Endpoint of /guild/upgrades.
+func UnscopedGuildUpgradesEndpoint(r *Requestor) Endpoint[int, GuildUpgrade] {
+	return NewEndpoint[int, GuildUpgrade](r, "/guild/upgrades")
 }
 
 // This resource returns a list of the guild upgrades
 // Return an array of ids for each type of currency.
-func (r *Requestor) UnscopedGuildUpgradeIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/guild/upgrades", &pointer)
-	return r
+func (r *Requestor) UnscopedGuildUpgradeIDs(pointer *[]int) *Requestor {
+	return UnscopedGuildUpgradesEndpoint(r).ids(pointer)
 }
 
 // This resource returns a list of the guild upgrades
 // Return a list of response objects
-func (r *Requestor) UnscopedGuildUpgrades(pointer *[]*GuildUpgrade, ids ...string) *Requestor {
-	r.collection("/guild/upgrades", &pointer, ids)
-	return r
+func (r *Requestor) UnscopedGuildUpgrades(pointer *[]*GuildUpgrade, ids ...int) *Requestor {
+	return UnscopedGuildUpgradesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns a list of the guild upgrades
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllUnscopedGuildUpgrades(pointer *[]*GuildUpgrade) *Requestor {
+	return UnscopedGuildUpgradesEndpoint(r).all(pointer)
 }
 
 // This resource returns a list of the guild upgrades
 // Return an object
-func (r *Requestor) UnscopedGuildUpgrade(pointer *GuildUpgrade, id string) *Requestor {
-	r.singleton("/guild/upgrades", &pointer, id)
-	return r
+func (r *Requestor) UnscopedGuildUpgrade(pointer *GuildUpgrade, id int) *Requestor {
+	return UnscopedGuildUpgradesEndpoint(r).get(pointer, id)
 }
@@ -0,0 +1,288 @@
+package gw2apitest
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// Mode selects how a Cassette answers the requests.
+type Mode int
+
+const (
+	// ModeReplay only replays the recorded interactions. The requests missing
+	// from the cassette are answered with a 501 Not Implemented naming them,
+	// never sent to the API.
+	ModeReplay Mode = iota
+	// ModeStrict only replays the recorded interactions. The requests missing
+	// from the cassette fail with ErrNotRecorded.
+	ModeStrict
+	// ModeUpdate sends every request to the API, replacing the recorded
+	// interactions with the new responses.
+	ModeUpdate
+	// ModeRecord replays the recorded interactions, and sends the requests
+	// missing from the cassette to the API to record them.
+	ModeRecord
+)
+
+// ErrNotRecorded is returned by a Cassette in ModeStrict for the requests
+// missing from the cassette, and is the text of the 501 Not Implemented
+// answered to them in ModeReplay.
+var ErrNotRecorded = errors.New("request not recorded in cassette")
+
+// redacted replaces the API key in the recorded interactions.
+const redacted = "REDACTED"
+
+// Cassette is an http.RoundTripper recording the interactions with the API
+// into a file, to replay them byte-for-byte without network access:
+//
+//	cassette, err := gw2apitest.LoadCassette("testdata/account.json", gw2apitest.ModeStrict)
+//	r := gw2api.NewRequestor().Transport(cassette)
+//
+// Requests are matched on their method, path, sorted query and schema
+// version. The API key is never recorded: the access_token parameter is
+// ignored by the matching, and the key is redacted from the responses.
+type Cassette struct {
+	// Transport sends the requests which are not replayed.
+	// http.DefaultTransport is used when nil.
+	Transport http.RoundTripper
+
+	path string
+	mode Mode
+
+	mu           sync.Mutex
+	interactions []*interaction
+	updated      map[recordedRequest]bool
+	modified     bool
+}
+
+type cassetteFile struct {
+	Interactions []*interaction `json:"interactions"`
+}
+
+type interaction struct {
+	Request  recordedRequest  `json:"request"`
+	Response recordedResponse `json:"response"`
+
+	replayed bool
+}
+
+type recordedRequest struct {
+	Method        string `json:"method"`
+	Path          string `json:"path"`
+	Query         string `json:"query,omitempty"`
+	SchemaVersion string `json:"schema_version,omitempty"`
+}
+
+type recordedResponse struct {
+	Status int         `json:"status"`
+	Header http.Header `json:"header,omitempty"`
+	Body   string      `json:"body"`
+}
+
+// LoadCassette loads the cassette stored at the path. A missing file gives
+// an empty cassette in ModeRecord and ModeUpdate, and an error in the replay
+// modes. Call Save to write the recorded interactions back to the file.
+func LoadCassette(path string, mode Mode) (*Cassette, error) {
+	c := &Cassette{
+		path:    path,
+		mode:    mode,
+		updated: make(map[recordedRequest]bool),
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) && (mode == ModeRecord || mode == ModeUpdate) {
+		return c, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("load cassette: %w", err)
+	}
+
+	var file cassetteFile
+	if err := json.Unmarshal(data, &file); err != nil {
+		return nil, fmt.Errorf("load cassette %s: %w", path, err)
+	}
+	c.interactions = file.Interactions
+	return c, nil
+}
+
+// Save writes the recorded interactions to the file of the cassette. It does
+// nothing when no interaction was recorded.
+func (c *Cassette) Save() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if !c.modified {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(cassetteFile{Interactions: c.interactions}, "", "  ")
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(filepath.Dir(c.path), 0o755); err != nil {
+		return err
+	}
+	if err := os.WriteFile(c.path, append(data, '\n'), 0o644); err != nil {
+		return err
+	}
+	c.modified = false
+	return nil
+}
+
+// RoundTrip replays the recorded response of the request, or sends it with
+// the Transport and records the response, depending on the Mode.
+func (c *Cassette) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := newRecordedRequest(req)
+
+	if c.mode != ModeUpdate {
+		if recorded, ok := c.replay(key); ok {
+			return recorded.response(req), nil
+		}
+		switch c.mode {
+		case ModeStrict:
+			return nil, fmt.Errorf("%w: %s", ErrNotRecorded, key)
+		case ModeReplay:
+			return notRecorded(key).response(req), nil
+		}
+	}
+
+	transport := c.Transport
+	if transport == nil {
+		transport = http.DefaultTransport
+	}
+	response, err := transport.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(response.Body)
+	response.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	response.Body = io.NopCloser(bytes.NewReader(body))
+
+	c.record(key, newRecordedResponse(response, body, apiKey(req)))
+	return response, nil
+}
+
+// replay returns the next recorded response of the request. Once every
+// interaction of the request is replayed, the last one is replayed again.
+func (c *Cassette) replay(key recordedRequest) (recordedResponse, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var last *interaction
+	for _, i := range c.interactions {
+		if i.Request != key {
+			continue
+		}
+		if !i.replayed {
+			i.replayed = true
+			return i.Response, true
+		}
+		last = i
+	}
+	if last == nil {
+		return recordedResponse{}, false
+	}
+	return last.Response, true
+}
+
+// record appends the interaction to the cassette. In ModeUpdate, the previous
+// recordings of the request are dropped the first time it is recorded.
+func (c *Cassette) record(key recordedRequest, response recordedResponse) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if c.mode == ModeUpdate && !c.updated[key] {
+		kept := c.interactions[:0]
+		for _, i := range c.interactions {
+			if i.Request != key {
+				kept = append(kept, i)
+			}
+		}
+		c.interactions = kept
+		c.updated[key] = true
+	}
+
+	c.interactions = append(c.interactions, &interaction{Request: key, Response: response, replayed: true})
+	c.modified = true
+}
+
+// String returns the request as "GET /v2/path?query".
+func (r recordedRequest) String() string {
+	return fmt.Sprintf("%s %s?%s", r.Method, r.Path, r.Query)
+}
+
+// notRecorded returns the response answered in ModeReplay to a request
+// missing from the cassette.
+func notRecorded(key recordedRequest) recordedResponse {
+	body, _ := json.Marshal(map[string]string{"text": fmt.Sprintf("%s: %s", ErrNotRecorded, key)})
+	return recordedResponse{
+		Status: http.StatusNotImplemented,
+		Header: http.Header{"Content-Type": []string{"application/json"}},
+		Body:   string(body),
+	}
+}
+
+// newRecordedRequest returns the matching key of the request, without its
+// access_token parameter.
+func newRecordedRequest(req *http.Request) recordedRequest {
+	query := req.URL.Query()
+	query.Del("access_token")
+	return recordedRequest{
+		Method:        req.Method,
+		Path:          req.URL.Path,
+		Query:         query.Encode(),
+		SchemaVersion: req.Header.Get("X-Schema-Version"),
+	}
+}
+
+// newRecordedResponse returns the response to record, with the API key and
+// the token id derived from it redacted.
+func newRecordedResponse(response *http.Response, body []byte, key string) recordedResponse {
+	header := response.Header.Clone()
+	header.Del("Set-Cookie")
+	header.Del("Content-Length")
+
+	recorded := string(body)
+	if key != "" {
+		recorded = strings.ReplaceAll(recorded, key, redacted)
+		if len(key) > 36 {
+			recorded = strings.ReplaceAll(recorded, key[:36], redacted)
+		}
+	}
+
+	return recordedResponse{
+		Status: response.StatusCode,
+		Header: header,
+		Body:   recorded,
+	}
+}
+
+func (r recordedResponse) response(req *http.Request) *http.Response {
+	header := r.Header.Clone()
+	if header == nil {
+		header = make(http.Header)
+	}
+
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", r.Status, http.StatusText(r.Status)),
+		StatusCode:    r.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(strings.NewReader(r.Body)),
+		ContentLength: int64(len(r.Body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,239 @@
+package gw2apitest_test
+
+import (
+	"errors"
+	"net/http"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+	"atomys.codes/gw2api-go/gw2apitest"
+)
+
+// offline is a Transport failing every request, to ensure a cassette never
+// reaches the network.
+type offline struct{}
+
+func (offline) RoundTrip(*http.Request) (*http.Response, error) {
+	return nil, errors.New("network access during replay")
+}
+
+func loadCassette(t *testing.T, path string, mode gw2apitest.Mode, transport http.RoundTripper) *gw2apitest.Cassette {
+	t.Helper()
+
+	cassette, err := gw2apitest.LoadCassette(path, mode)
+	if err != nil {
+		t.Fatalf("LoadCassette() = %v, want no error", err)
+	}
+	cassette.Transport = transport
+	return cassette
+}
+
+func TestCassette_RecordReplay(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	type result struct {
+		account gw2api.Account
+		worlds  []*gw2api.World
+	}
+	run := func(cassette *gw2apitest.Cassette) (got result) {
+		r := gw2api.NewRequestor().Transport(cassette).Auth(gw2apitest.KeyValid)
+		if err := r.Account(&got.account).Err(); err != nil {
+			t.Fatalf("Requestor.Account() = %v, want no error", err)
+		}
+		if err := r.Worlds(&got.worlds, 1001, 2101).Err(); err != nil {
+			t.Fatalf("Requestor.Worlds() = %v, want no error", err)
+		}
+		return got
+	}
+
+	recorder := loadCassette(t, path, gw2apitest.ModeRecord, srv.Client().Transport)
+	recorded := run(recorder)
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Cassette.Save() = %v, want no error", err)
+	}
+	sent := len(srv.Requests())
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(string(data), gw2apitest.KeyValid[:36]) {
+		t.Errorf("cassette contains the API key:\n%s", data)
+	}
+
+	replayed := run(loadCassette(t, path, gw2apitest.ModeStrict, offline{}))
+	if !reflect.DeepEqual(replayed, recorded) {
+		t.Errorf("replayed %+v, want %+v", replayed, recorded)
+	}
+	if len(srv.Requests()) != sent {
+		t.Errorf("replay sent %d requests to the API, want none", len(srv.Requests())-sent)
+	}
+}
+
+func TestCassette_Strict(t *testing.T) {
+	if _, err := gw2apitest.LoadCassette(filepath.Join(t.TempDir(), "missing.json"), gw2apitest.ModeStrict); err == nil {
+		t.Errorf("LoadCassette() of a missing file = nil, want error")
+	}
+
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder := loadCassette(t, path, gw2apitest.ModeRecord, srv.Client().Transport)
+	var world gw2api.World
+	if err := gw2api.NewRequestor().Transport(recorder).World(&world, 2101).Err(); err != nil {
+		t.Fatalf("Requestor.World() = %v, want no error", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := gw2api.NewRequestor().Transport(loadCassette(t, path, gw2apitest.ModeStrict, offline{}))
+	tests := []struct {
+		name    string
+		r       *gw2api.Requestor
+		id      int
+		wantErr error
+	}{
+		{"recorded request", r, 2101, nil},
+		{"other query", r, 1001, gw2apitest.ErrNotRecorded},
+		{"other language", r.Lang(gw2api.LangFR), 2101, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var world gw2api.World
+			if err := tt.r.World(&world, tt.id).Err(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("Requestor.World() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCassette_Replay(t *testing.T) {
+	if _, err := gw2apitest.LoadCassette(filepath.Join(t.TempDir(), "missing.json"), gw2apitest.ModeReplay); err == nil {
+		t.Errorf("LoadCassette() of a missing file = nil, want error")
+	}
+
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	recorder := loadCassette(t, path, gw2apitest.ModeRecord, srv.Client().Transport)
+	var world gw2api.World
+	if err := gw2api.NewRequestor().Transport(recorder).World(&world, 2101).Err(); err != nil {
+		t.Fatalf("Requestor.World() = %v, want no error", err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	r := gw2api.NewRequestor().Transport(loadCassette(t, path, gw2apitest.ModeReplay, offline{}))
+	if err := r.World(&world, 2101).Err(); err != nil {
+		t.Errorf("Requestor.World() of a recorded request = %v, want no error", err)
+	}
+
+	var apiErr *gw2api.APIError
+	err := r.World(&world, 1001).Err()
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotImplemented {
+		t.Fatalf("Requestor.World() of an unrecorded request = %v, want a 501 API error", err)
+	}
+	if want := "GET /v2/worlds?id=1001"; !strings.Contains(apiErr.Text, want) {
+		t.Errorf("APIError.Text = %q, want it to name %q", apiErr.Text, want)
+	}
+}
+
+func TestCassette_Matching(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	send := func(transport http.RoundTripper, method, target, schema string) error {
+		req, _ := http.NewRequest(method, "https://api.guildwars2.com/v2"+target, nil)
+		req.Header.Set("X-Schema-Version", schema)
+		resp, err := transport.RoundTrip(req)
+		if err != nil {
+			return err
+		}
+		return resp.Body.Close()
+	}
+
+	recorder := loadCassette(t, path, gw2apitest.ModeRecord, srv.Client().Transport)
+	if err := send(recorder, http.MethodGet, "/worlds?lang=en&ids=1001,2101&access_token=secret", "2021-08-14T00:00:00Z"); err != nil {
+		t.Fatal(err)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	player := loadCassette(t, path, gw2apitest.ModeStrict, offline{})
+	tests := []struct {
+		name    string
+		method  string
+		target  string
+		schema  string
+		wantErr bool
+	}{
+		{"same request", http.MethodGet, "/worlds?lang=en&ids=1001,2101&access_token=secret", "2021-08-14T00:00:00Z", false},
+		{"sorted query", http.MethodGet, "/worlds?ids=1001,2101&lang=en", "2021-08-14T00:00:00Z", false},
+		{"other access token", http.MethodGet, "/worlds?ids=1001,2101&lang=en&access_token=other", "2021-08-14T00:00:00Z", false},
+		{"other method", http.MethodHead, "/worlds?ids=1001,2101&lang=en", "2021-08-14T00:00:00Z", true},
+		{"other path", http.MethodGet, "/titles?ids=1001,2101&lang=en", "2021-08-14T00:00:00Z", true},
+		{"other query", http.MethodGet, "/worlds?ids=1001&lang=en", "2021-08-14T00:00:00Z", true},
+		{"other schema version", http.MethodGet, "/worlds?ids=1001,2101&lang=en", "2019-12-19T00:00:00Z", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := send(player, tt.method, tt.target, tt.schema); (err != nil) != tt.wantErr {
+				t.Errorf("Cassette.RoundTrip() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestCassette_Update(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	path := filepath.Join(t.TempDir(), "cassette.json")
+
+	record := func(mode gw2apitest.Mode) {
+		cassette := loadCassette(t, path, mode, srv.Client().Transport)
+		var build gw2api.Build
+		if err := gw2api.NewRequestor().Transport(cassette).Build(&build, 0).Err(); err != nil {
+			t.Fatalf("Requestor.Build() = %v, want no error", err)
+		}
+		if err := cassette.Save(); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	tests := []struct {
+		name     string
+		mode     gw2apitest.Mode
+		wantSent int
+	}{
+		{"first recording", gw2apitest.ModeRecord, 1},
+		{"replay", gw2apitest.ModeReplay, 1},
+		{"update", gw2apitest.ModeUpdate, 2},
+		{"update again", gw2apitest.ModeUpdate, 3},
+	}
+	for _, tt := range tests {
+		record(tt.mode)
+		if got := len(srv.Requests()); got != tt.wantSent {
+			t.Errorf("%s: sent %d requests to the API, want %d", tt.name, got, tt.wantSent)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if got := strings.Count(string(data), `"path": "/v2/build"`); got != 1 {
+		t.Errorf("cassette records /v2/build %d times, want 1", got)
+	}
+}
@@ -0,0 +1,170 @@
+package gw2apitest
+
+import (
+	"strings"
+)
+
+// route describes an endpoint served by the Server. Its template uses `:id`
+// for the dynamic segments of the path, stored as `_` in the fixtures.
+type route struct {
+	template string
+	// The scopes required by the endpoint, nil when the endpoint is public.
+	scopes []string
+	// The field identifying the objects of a bulk-expanded endpoint, "#" when
+	// the objects are identified by their position.
+	idField string
+	// True when the endpoint rejects `ids=all`.
+	noAll bool
+}
+
+func scopes(names ...string) []string {
+	return append([]string{"account"}, names...)
+}
+
+// routes lists the endpoints served by the Server. Static segments are
+// matched before the dynamic ones, so `/guild/search` is listed before
+// `/guild/:id`.
+var routes = []route{
+	{template: "/account", scopes: scopes()},
+	{template: "/account/achievements", scopes: scopes("progression")},
+	{template: "/account/bank", scopes: scopes("inventories")},
+	{template: "/account/buildstorage", scopes: scopes(), idField: "#"},
+	{template: "/account/dailycrafting", scopes: scopes("progression")},
+	{template: "/account/dungeons", scopes: scopes("progression")},
+	{template: "/account/dyes", scopes: scopes("unlocks")},
+	{template: "/account/emotes", scopes: scopes("unlocks")},
+	{template: "/account/finishers", scopes: scopes("unlocks")},
+	{template: "/account/gliders", scopes: scopes("unlocks")},
+	{template: "/account/home", scopes: scopes()},
+	{template: "/account/home/cats", scopes: scopes("unlocks")},
+	{template: "/account/home/nodes", scopes: scopes("unlocks")},
+	{template: "/account/inventory", scopes: scopes("inventories")},
+	{template: "/account/legendaryarmory", scopes: scopes("inventories", "unlocks")},
+	{template: "/account/luck", scopes: scopes("progression", "unlocks")},
+	{template: "/account/mailcarriers", scopes: scopes("unlocks")},
+	{template: "/account/mapchests", scopes: scopes("progression")},
+	{template: "/account/masteries", scopes: scopes("progression")},
+	{template: "/account/mastery/points", scopes: scopes("progression")},
+	{template: "/account/materials", scopes: scopes("inventories")},
+	{template: "/account/minis", scopes: scopes("unlocks")},
+	{template: "/account/mounts", scopes: scopes()},
+	{template: "/account/mounts/skins", scopes: scopes("unlocks")},
+	{template: "/account/mounts/types", scopes: scopes("unlocks")},
+	{template: "/account/novelties", scopes: scopes("unlocks")},
+	{template: "/account/outfits", scopes: scopes("unlocks")},
+	{template: "/account/pvp/heroes", scopes: scopes("unlocks")},
+	{template: "/account/raids", scopes: scopes("progression")},
+	{template: "/account/recipes", scopes: scopes("unlocks")},
+	{template: "/account/skins", scopes: scopes("unlocks")},
+	{template: "/account/titles", scopes: scopes("unlocks")},
+	{template: "/account/wallet", scopes: scopes("wallet")},
+	{template: "/account/worldbosses", scopes: scopes("progression")},
+
+	{template: "/achievements", idField: "id", noAll: true},
+	{template: "/achievements/categories", idField: "id"},
+	{template: "/achievements/daily"},
+	{template: "/achievements/daily/tomorrow"},
+	{template: "/achievements/groups", idField: "id"},
+
+	{template: "/backstory/answers", idField: "id"},
+	{template: "/backstory/questions", idField: "id"},
+
+	{template: "/build"},
+
+	{template: "/characters", scopes: scopes("characters"), idField: "name"},
+	{template: "/characters/:id/backstory", scopes: scopes("characters")},
+	{template: "/characters/:id/core", scopes: scopes("characters")},
+	{template: "/characters/:id/crafting", scopes: scopes("characters")},
+	{template: "/characters/:id/equipment", scopes: scopes("characters", "builds", "inventories")},
+	{template: "/characters/:id/heropoints", scopes: scopes("characters", "progression")},
+	{template: "/characters/:id/inventory", scopes: scopes("characters", "inventories")},
+	{template: "/characters/:id/quests", scopes: scopes("characters", "progression")},
+	{template: "/characters/:id/recipes", scopes: scopes("characters", "inventories")},
+	{template: "/characters/:id/sab", scopes: scopes("characters")},
+	{template: "/characters/:id/skills", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/specializations", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/training", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/buildtabs", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/buildtabs/active", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/buildtabs/:id", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/equipmenttabs", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/equipmenttabs/active", scopes: scopes("characters", "builds")},
+	{template: "/characters/:id/equipmenttabs/:id", scopes: scopes("characters", "builds")},
+
+	{template: "/colors", idField: "id"},
+
+	{template: "/commerce/delivery", scopes: scopes("tradingpost")},
+	{template: "/commerce/exchange/coins"},
+	{template: "/commerce/exchange/gems"},
+	{template: "/commerce/listings", idField: "id", noAll: true},
+	{template: "/commerce/prices", idField: "id", noAll: true},
+	{template: "/commerce/transactions/current/buys", scopes: scopes("tradingpost")},
+	{template: "/commerce/transactions/current/sells", scopes: scopes("tradingpost")},
+	{template: "/commerce/transactions/history/buys", scopes: scopes("tradingpost")},
+	{template: "/commerce/transactions/history/sells", scopes: scopes("tradingpost")},
+
+	{template: "/continents", idField: "id"},
+	{template: "/createsubtoken", scopes: scopes()},
+	{template: "/currencies", idField: "id"},
+	{template: "/dailycrafting"},
+	{template: "/dungeons", idField: "id"},
+	{template: "/emblem/backgrounds", idField: "id"},
+	{template: "/emblem/foregrounds", idField: "id"},
+	{template: "/emotes", idField: "id"},
+	{template: "/files", idField: "id"},
+	{template: "/finishers", idField: "id"},
+	{template: "/gliders", idField: "id"},
+
+	{template: "/guild/permissions", idField: "id"},
+	{template: "/guild/search"},
+	{template: "/guild/upgrades", idField: "id"},
+	{template: "/guild/:id"},
+	{template: "/guild/:id/log", scopes: scopes("guilds")},
+	{template: "/guild/:id/members", scopes: scopes("guilds")},
+	{template: "/guild/:id/ranks", scopes: scopes("guilds")},
+	{template: "/guild/:id/stash", scopes: scopes("guilds")},
+	{template: "/guild/:id/storage", scopes: scopes("guilds")},
+	{template: "/guild/:id/treasury", scopes: scopes("guilds")},
+	{template: "/guild/:id/upgrades", scopes: scopes("guilds")},
+
+	{template: "/specializations", idField: "id"},
+	{template: "/stories", idField: "id"},
+	{template: "/stories/seasons", idField: "id"},
+	{template: "/titles", idField: "id"},
+	{template: "/worlds", idField: "id"},
+	{template: "/worldbosses", idField: "id"},
+
+	{template: "/wvw/abilities", idField: "id"},
+	{template: "/wvw/matches", idField: "id"},
+	{template: "/wvw/matches/overview", idField: "id"},
+	{template: "/wvw/matches/scores", idField: "id"},
+	{template: "/wvw/matches/stats", idField: "id"},
+	{template: "/wvw/objectives", idField: "id"},
+	{template: "/wvw/ranks", idField: "id"},
+	{template: "/wvw/upgrades", idField: "id"},
+}
+
+// match returns the route of the path, or false when no route matches.
+func match(path string) (route, bool) {
+	segments := strings.Split(strings.Trim(path, "/"), "/")
+
+Routes:
+	for _, r := range routes {
+		template := strings.Split(strings.Trim(r.template, "/"), "/")
+		if len(template) != len(segments) {
+			continue
+		}
+		for i, segment := range template {
+			if segment != ":id" && segment != segments[i] {
+				continue Routes
+			}
+		}
+		return r, true
+	}
+	return route{}, false
+}
+
+// fixture returns the name of the embedded fixture served by the route.
+func (r route) fixture() string {
+	return "fixtures" + strings.ReplaceAll(r.template, ":id", "_") + ".json"
+}
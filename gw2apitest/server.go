@@ -0,0 +1,448 @@
+// Package gw2apitest provides a fake Guild Wars 2 API server serving
+// fixtures for every endpoint wrapped by gw2api, to test code using the
+// library without reaching the live API.
+//
+//	srv := gw2apitest.NewServer()
+//	defer srv.Close()
+//
+//	r := gw2api.NewRequestor().Client(srv.Client())
+//	err := r.Auth(gw2apitest.KeyValid).Account(&account).Err()
+//
+// It also provides Cassette, recording the interactions with the live API
+// once to replay them in the tests.
+package gw2apitest
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"math"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// The API keys known by a new Server.
+const (
+	// KeyValid is granted every scope.
+	KeyValid = "00000000-0000-0000-0000-00000000000000000000-0000-0000-0000-000000000000"
+	// KeyMissingScope is only granted the account scope.
+	KeyMissingScope = "11111111-1111-1111-1111-11111111111111111111-1111-1111-1111-111111111111"
+	// KeyInvalid is rejected as an invalid key.
+	KeyInvalid = "FFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFFFFFFFFFF-FFFF-FFFF-FFFF-FFFFFFFFFFFF"
+)
+
+// AllScopes lists every scope an API key can be granted.
+var AllScopes = []string{
+	"account", "builds", "characters", "guilds", "inventories",
+	"progression", "pvp", "tradingpost", "unlocks", "wallet",
+}
+
+// MaxIDs is the maximum amount of ids accepted in a single request.
+const MaxIDs = 200
+
+//go:embed all:fixtures
+var fixtures embed.FS
+
+// Server is a fake Guild Wars 2 API answering from the embedded fixtures.
+// It checks the scopes of the API key through the same rules as the live API,
+// answers 206 Partial Content and 404 for invalid ids, paginates with the
+// page and page_size parameters, and can simulate the rate limiting.
+type Server struct {
+	*httptest.Server
+
+	mu       sync.Mutex
+	keys     map[string][]string
+	limit    int
+	window   time.Duration
+	start    time.Time
+	count    int
+	requests []string
+}
+
+// NewServer starts a Server knowing the KeyValid and KeyMissingScope API
+// keys. The caller should call Close when finished, to shut it down.
+func NewServer() *Server {
+	s := &Server{
+		keys: map[string][]string{
+			KeyValid:        AllScopes,
+			KeyMissingScope: {"account"},
+		},
+	}
+	s.Server = httptest.NewServer(http.HandlerFunc(s.serveHTTP))
+	return s
+}
+
+// AddKey registers an API key granted the given scopes.
+func (s *Server) AddKey(key string, scopes ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.keys[key] = scopes
+}
+
+// RateLimit answers 429 Too Many Requests to the requests exceeding the limit
+// within each window. Give a zero limit to disable the rate limiting.
+func (s *Server) RateLimit(limit int, window time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.limit, s.window = limit, window
+	s.start, s.count = time.Time{}, 0
+}
+
+// Requests returns the path and query of the requests received by the
+// Server, in order.
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// Client returns an http.Client sending the requests made to any host to the
+// Server, so a Requestor can be pointed at it:
+//
+//	r := gw2api.NewRequestor().Client(srv.Client())
+func (s *Server) Client() *http.Client {
+	target, _ := url.Parse(s.URL)
+	return &http.Client{Transport: &rewriteTransport{
+		target: target,
+		base:   s.Server.Client().Transport,
+	}}
+}
+
+// rewriteTransport sends the requests to the target host.
+type rewriteTransport struct {
+	target *url.URL
+	base   http.RoundTripper
+}
+
+func (t *rewriteTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	req = req.Clone(req.Context())
+	req.URL.Scheme = t.target.Scheme
+	req.URL.Host = t.target.Host
+	req.Host = t.target.Host
+	return t.base.RoundTrip(req)
+}
+
+func (s *Server) serveHTTP(w http.ResponseWriter, req *http.Request) {
+	s.mu.Lock()
+	s.requests = append(s.requests, req.URL.RequestURI())
+	s.mu.Unlock()
+
+	w.Header().Set("Content-Type", "application/json; charset=utf-8")
+	if lang := req.Header.Get("Accept-Language"); lang != "" {
+		w.Header().Set("Content-Language", lang)
+	}
+
+	if wait, limited := s.rateLimited(w.Header()); limited {
+		w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(wait.Seconds()))))
+		writeError(w, http.StatusTooManyRequests, "too many requests")
+		return
+	}
+
+	path := strings.TrimPrefix(req.URL.Path, "/v2")
+	key := apiKey(req)
+
+	if path == "/tokeninfo" {
+		s.serveTokenInfo(w, key)
+		return
+	}
+
+	r, ok := match(path)
+	if !ok {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	if r.scopes != nil {
+		if status, text := s.authorize(key, r.scopes); status != http.StatusOK {
+			writeError(w, status, text)
+			return
+		}
+	}
+
+	data, err := fixtures.ReadFile(r.fixture())
+	if err != nil {
+		writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+
+	query := req.URL.Query()
+	switch {
+	case r.idField != "" && query.Get("id") != "":
+		serveID(w, r, data, query.Get("id"))
+	case r.idField != "" && query.Get("ids") != "":
+		serveIDs(w, r, data, query.Get("ids"))
+	case query.Get("page") != "" || query.Get("page_size") != "":
+		servePage(w, data, query)
+	case r.idField != "":
+		serveIndex(w, r, data)
+	default:
+		writeJSON(w, http.StatusOK, json.RawMessage(data))
+	}
+}
+
+// rateLimited counts the request in the current window, and returns the time
+// left before the next window when the limit is exceeded.
+func (s *Server) rateLimited(header http.Header) (time.Duration, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.limit <= 0 {
+		return 0, false
+	}
+
+	now := time.Now()
+	if s.start.IsZero() || now.Sub(s.start) >= s.window {
+		s.start, s.count = now, 0
+	}
+	s.count++
+
+	header.Set("X-Rate-Limit-Limit", strconv.Itoa(s.limit))
+	remaining := s.limit - s.count
+	if remaining < 0 {
+		remaining = 0
+	}
+	header.Set("X-Rate-Limit-Remaining", strconv.Itoa(remaining))
+	if s.count > s.limit {
+		return s.window - now.Sub(s.start), true
+	}
+	return 0, false
+}
+
+// authorize checks the API key grants the scopes, returning the status and
+// the error text of the API otherwise.
+func (s *Server) authorize(key string, required []string) (int, string) {
+	if key == "" {
+		return http.StatusUnauthorized, "Invalid access token"
+	}
+
+	s.mu.Lock()
+	granted, ok := s.keys[key]
+	s.mu.Unlock()
+	if !ok {
+		return http.StatusUnauthorized, "Invalid access token"
+	}
+
+	for _, scope := range required {
+		if !contains(granted, scope) {
+			return http.StatusForbidden, "requires scope " + scope
+		}
+	}
+	return http.StatusOK, ""
+}
+
+func (s *Server) serveTokenInfo(w http.ResponseWriter, key string) {
+	if status, text := s.authorize(key, nil); status != http.StatusOK {
+		writeError(w, status, text)
+		return
+	}
+
+	s.mu.Lock()
+	scopes := s.keys[key]
+	s.mu.Unlock()
+
+	id := key
+	if len(id) > 36 {
+		id = id[:36]
+	}
+	writeJSON(w, http.StatusOK, map[string]interface{}{
+		"id":          id,
+		"name":        "gw2apitest",
+		"permissions": scopes,
+		"type":        "APIKey",
+	})
+}
+
+// serveIndex answers the ids of all the objects of a bulk-expanded endpoint.
+func serveIndex(w http.ResponseWriter, r route, data []byte) {
+	objects, err := decodeObjects(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	ids := make([]json.RawMessage, len(objects))
+	for i, object := range objects {
+		ids[i] = r.id(i, object)
+	}
+	writeList(w, http.StatusOK, ids, len(ids))
+}
+
+// serveID answers the object with the id, or 404 when it does not exist.
+func serveID(w http.ResponseWriter, r route, data []byte, id string) {
+	objects, err := decodeObjects(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	for i, object := range objects {
+		if rawID(r.id(i, object)) == id {
+			writeJSON(w, http.StatusOK, object)
+			return
+		}
+	}
+	writeError(w, http.StatusNotFound, "no such id")
+}
+
+// serveIDs answers the objects with the ids, with a 206 Partial Content when
+// some of them do not exist and a 404 when none exists.
+func serveIDs(w http.ResponseWriter, r route, data []byte, ids string) {
+	objects, err := decodeObjects(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	if ids == "all" {
+		if r.noAll || len(objects) > MaxIDs {
+			writeError(w, http.StatusBadRequest, fmt.Sprintf("id list too long; this endpoint is limited to %d ids at once", MaxIDs))
+			return
+		}
+		writeList(w, http.StatusOK, objects, len(objects))
+		return
+	}
+
+	requested := strings.Split(ids, ",")
+	if len(requested) > MaxIDs {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("id list too long; this endpoint is limited to %d ids at once", MaxIDs))
+		return
+	}
+
+	found := make([]json.RawMessage, 0, len(requested))
+	for _, id := range requested {
+		for i, object := range objects {
+			if rawID(r.id(i, object)) == id {
+				found = append(found, object)
+				break
+			}
+		}
+	}
+
+	switch {
+	case len(found) == 0:
+		writeError(w, http.StatusNotFound, "all ids provided are invalid")
+	case len(found) < len(requested):
+		writeList(w, http.StatusPartialContent, found, len(objects))
+	default:
+		writeList(w, http.StatusOK, found, len(objects))
+	}
+}
+
+// servePage answers a page of the objects with the pagination headers.
+func servePage(w http.ResponseWriter, data []byte, query url.Values) {
+	objects, err := decodeObjects(data)
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+
+	page, _ := strconv.Atoi(query.Get("page"))
+	size, err := strconv.Atoi(query.Get("page_size"))
+	if err != nil || size < 1 {
+		size = 50
+	}
+	if size > MaxIDs {
+		size = MaxIDs
+	}
+
+	total := (len(objects) + size - 1) / size
+	if total == 0 {
+		total = 1
+	}
+	if page < 0 || page >= total {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf("page out of range. Use page values 0 - %d.", total-1))
+		return
+	}
+
+	end := (page + 1) * size
+	if end > len(objects) {
+		end = len(objects)
+	}
+
+	header := w.Header()
+	header.Set("X-Page-Total", strconv.Itoa(total))
+	header.Set("X-Page-Size", strconv.Itoa(size))
+	header.Set("Link", pageLinks(page, size, total))
+	writeList(w, http.StatusOK, objects[page*size:end], len(objects))
+}
+
+// pageLinks returns the Link header of a page.
+func pageLinks(page, size, total int) string {
+	link := func(page int, rel string) string {
+		return fmt.Sprintf("<?page=%d&page_size=%d>; rel=%s", page, size, rel)
+	}
+
+	links := []string{link(page, "self"), link(0, "first")}
+	if page > 0 {
+		links = append(links, link(page-1, "previous"))
+	}
+	if page < total-1 {
+		links = append(links, link(page+1, "next"))
+	}
+	return strings.Join(append(links, link(total-1, "last")), ", ")
+}
+
+// id returns the raw id of the object at the index.
+func (r route) id(index int, object json.RawMessage) json.RawMessage {
+	if r.idField == "#" {
+		return json.RawMessage(strconv.Itoa(index))
+	}
+
+	var fields map[string]json.RawMessage
+	_ = json.Unmarshal(object, &fields)
+	return fields[r.idField]
+}
+
+// rawID returns the id as given in a query, without the quotes of strings.
+func rawID(id json.RawMessage) string {
+	var s string
+	if err := json.Unmarshal(id, &s); err == nil {
+		return s
+	}
+	return string(id)
+}
+
+func decodeObjects(data []byte) ([]json.RawMessage, error) {
+	var objects []json.RawMessage
+	if err := json.Unmarshal(data, &objects); err != nil {
+		return nil, fmt.Errorf("invalid fixture: %w", err)
+	}
+	return objects, nil
+}
+
+// apiKey returns the API key of the request, given by the Authorization
+// header or the access_token parameter.
+func apiKey(req *http.Request) string {
+	if token := req.URL.Query().Get("access_token"); token != "" {
+		return token
+	}
+	return strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+}
+
+func writeList(w http.ResponseWriter, status int, list []json.RawMessage, total int) {
+	w.Header().Set("X-Result-Count", strconv.Itoa(len(list)))
+	w.Header().Set("X-Result-Total", strconv.Itoa(total))
+	writeJSON(w, status, list)
+}
+
+func writeError(w http.ResponseWriter, status int, text string) {
+	writeJSON(w, status, map[string]string{"text": text})
+}
+
+func writeJSON(w http.ResponseWriter, status int, v interface{}) {
+	w.WriteHeader(status)
+	_ = json.NewEncoder(w).Encode(v)
+}
+
+func contains(list []string, s string) bool {
+	for _, e := range list {
+		if e == s {
+			return true
+		}
+	}
+	return false
+}
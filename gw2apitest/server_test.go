@@ -0,0 +1,175 @@
+package gw2apitest_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go/gw2apitest"
+)
+
+// get requests the path from the Server, and returns the response along with
+// its X-Result-Count header.
+func get(t *testing.T, srv *gw2apitest.Server, path string, key string) (*http.Response, string) {
+	t.Helper()
+
+	req, err := http.NewRequest(http.MethodGet, "https://api.guildwars2.com/v2"+path, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if key != "" {
+		req.Header.Set("Authorization", "Bearer "+key)
+	}
+
+	resp, err := srv.Client().Do(req)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer resp.Body.Close()
+
+	if err := json.NewDecoder(resp.Body).Decode(&json.RawMessage{}); err != nil {
+		t.Fatalf("GET %s: invalid JSON body: %v", path, err)
+	}
+	return resp, resp.Header.Get("X-Result-Count")
+}
+
+func TestServer(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		name       string
+		path       string
+		key        string
+		wantStatus int
+		wantCount  string
+	}{
+		{"public endpoint", "/worlds/", "", http.StatusOK, "3"},
+		{"single id", "/worlds?id=2101", "", http.StatusOK, ""},
+		{"unknown id", "/worlds?id=1", "", http.StatusNotFound, ""},
+		{"all ids", "/worlds?ids=all", "", http.StatusOK, "3"},
+		{"some ids missing", "/worlds?ids=2101,1", "", http.StatusPartialContent, "1"},
+		{"all ids missing", "/worlds?ids=1,2", "", http.StatusNotFound, ""},
+		{"all ids refused", "/achievements?ids=all", "", http.StatusBadRequest, ""},
+		{"string ids", "/worldbosses?ids=the_shatterer", "", http.StatusOK, "1"},
+		{"dynamic segment", "/characters/Fake%20Character/core", gw2apitest.KeyValid, http.StatusOK, ""},
+		{"unknown endpoint", "/unknown", "", http.StatusNotFound, ""},
+		{"without api key", "/account", "", http.StatusUnauthorized, ""},
+		{"with invalid api key", "/account", gw2apitest.KeyInvalid, http.StatusUnauthorized, ""},
+		{"with api key", "/account", gw2apitest.KeyMissingScope, http.StatusOK, ""},
+		{"with missing scope", "/account/bank", gw2apitest.KeyMissingScope, http.StatusForbidden, ""},
+		{"with every scope", "/account/bank", gw2apitest.KeyValid, http.StatusOK, ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp, count := get(t, srv, tt.path, tt.key)
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("GET %s = %d, want %d", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+			if count != tt.wantCount {
+				t.Errorf("GET %s returned %q results, want %q", tt.path, count, tt.wantCount)
+			}
+		})
+	}
+}
+
+func TestServer_TokenInfo(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	srv.AddKey("custom-key", "account", "wallet")
+
+	tests := []struct {
+		name        string
+		key         string
+		wantStatus  int
+		permissions []string
+	}{
+		{"with every scope", gw2apitest.KeyValid, http.StatusOK, gw2apitest.AllScopes},
+		{"with missing scope", gw2apitest.KeyMissingScope, http.StatusOK, []string{"account"}},
+		{"with added key", "custom-key", http.StatusOK, []string{"account", "wallet"}},
+		{"with invalid api key", gw2apitest.KeyInvalid, http.StatusUnauthorized, nil},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			req, _ := http.NewRequest(http.MethodGet, "https://api.guildwars2.com/v2/tokeninfo?access_token="+tt.key, nil)
+			resp, err := srv.Client().Do(req)
+			if err != nil {
+				t.Fatal(err)
+			}
+			defer resp.Body.Close()
+
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("GET /tokeninfo = %d, want %d", resp.StatusCode, tt.wantStatus)
+			}
+			var info struct {
+				Permissions []string `json:"permissions"`
+			}
+			if err := json.NewDecoder(resp.Body).Decode(&info); err != nil {
+				t.Fatal(err)
+			}
+			if strings.Join(info.Permissions, ",") != strings.Join(tt.permissions, ",") {
+				t.Errorf("GET /tokeninfo permissions = %v, want %v", info.Permissions, tt.permissions)
+			}
+		})
+	}
+}
+
+func TestServer_Pagination(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+
+	tests := []struct {
+		path       string
+		wantStatus int
+		wantTotal  string
+		wantCount  string
+		wantNext   bool
+	}{
+		{"/worlds?page=0&page_size=2", http.StatusOK, "2", "2", true},
+		{"/worlds?page=1&page_size=2", http.StatusOK, "2", "1", false},
+		{"/worlds?page=2&page_size=2", http.StatusBadRequest, "", "", false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			resp, count := get(t, srv, tt.path, "")
+			if resp.StatusCode != tt.wantStatus {
+				t.Fatalf("GET %s = %d, want %d", tt.path, resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get("X-Page-Total"); got != tt.wantTotal {
+				t.Errorf("GET %s X-Page-Total = %q, want %q", tt.path, got, tt.wantTotal)
+			}
+			if count != tt.wantCount {
+				t.Errorf("GET %s returned %q results, want %q", tt.path, count, tt.wantCount)
+			}
+			if got := strings.Contains(resp.Header.Get("Link"), "rel=next"); got != tt.wantNext {
+				t.Errorf("GET %s Link has next = %v, want %v", tt.path, got, tt.wantNext)
+			}
+		})
+	}
+}
+
+func TestServer_RateLimit(t *testing.T) {
+	srv := gw2apitest.NewServer()
+	defer srv.Close()
+	srv.RateLimit(2, time.Minute)
+
+	for i, want := range []int{http.StatusOK, http.StatusOK, http.StatusTooManyRequests} {
+		resp, _ := get(t, srv, "/build", "")
+		if resp.StatusCode != want {
+			t.Fatalf("request %d = %d, want %d", i, resp.StatusCode, want)
+		}
+		if want == http.StatusTooManyRequests && resp.Header.Get("Retry-After") == "" {
+			t.Errorf("request %d has no Retry-After header", i)
+		}
+	}
+
+	srv.RateLimit(0, 0)
+	if resp, _ := get(t, srv, "/build", ""); resp.StatusCode != http.StatusOK {
+		t.Errorf("request without rate limit = %d, want %d", resp.StatusCode, http.StatusOK)
+	}
+	if got := len(srv.Requests()); got != 4 {
+		t.Errorf("Server.Requests() has %d requests, want 4", got)
+	}
+}
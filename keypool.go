@@ -0,0 +1,349 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"sync"
+	"time"
+)
+
+// DefaultKeyQuarantine is the duration a KeyPool stops using a key the API
+// answered as invalid.
+const DefaultKeyQuarantine = 10 * time.Minute
+
+var ErrNoKeyAvailable = errors.New("no API key of the pool available")
+
+// PoolKey is an API key of a KeyPool.
+type PoolKey struct {
+	// The API key or subtoken.
+	Key string
+	// The name of the account of the key, like "Account.1234". It is fetched
+	// from /account when needed to pin a request to the account, see
+	// Requestor.ForAccount.
+	Account string
+	// The budget of requests of the key, nil when not limited. Each request
+	// performed with the key takes one request of its budget, including each
+	// chunk of a collection and each page of a Paginator.
+	Limiter *RateLimiter
+}
+
+// KeyHealth is the state of a key of a KeyPool.
+type KeyHealth struct {
+	Key     string
+	Account string
+	// The amount of requests performed with the key.
+	Requests int
+	// The amount of those requests which failed because of the key: refused
+	// or rate limited by the API, or not sent. Invalid ids, server errors and
+	// cancelled requests are not counted.
+	Failures int
+	// The error of the last request failed because of the key, nil if none.
+	LastErr error
+	// The time until which the key is not drawn, zero when not quarantined.
+	QuarantinedUntil time.Time
+}
+
+// KeyPool holds the API keys of several accounts, drawn by the Requestors
+// using it for their authenticated requests: a key granting the scopes of the
+// endpoint is chosen in turn, within its budget, spreading the requests
+// between the keys. The keys answered as invalid by the API are quarantined.
+// A KeyPool is safe for concurrent use and can be shared between Requestors.
+type KeyPool struct {
+	mu         sync.Mutex
+	keys       []*pooledKey
+	next       int
+	quarantine time.Duration
+}
+
+type pooledKey struct {
+	PoolKey
+	requests         int
+	failures         int
+	lastErr          error
+	quarantinedUntil time.Time
+}
+
+// NewKeyPool returns a KeyPool holding the keys.
+func NewKeyPool(keys ...PoolKey) *KeyPool {
+	pool := &KeyPool{quarantine: DefaultKeyQuarantine}
+	pool.Add(keys...)
+	return pool
+}
+
+// Add adds keys to the pool. A key already in the pool is replaced, keeping
+// its health.
+func (p *KeyPool) Add(keys ...PoolKey) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+Keys:
+	for _, key := range keys {
+		for _, k := range p.keys {
+			if k.Key == key.Key {
+				k.PoolKey = key
+				continue Keys
+			}
+		}
+		p.keys = append(p.keys, &pooledKey{PoolKey: key})
+	}
+}
+
+// Remove removes the key from the pool.
+func (p *KeyPool) Remove(key string) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	for i, k := range p.keys {
+		if k.Key == key {
+			p.keys = append(p.keys[:i:i], p.keys[i+1:]...)
+			return
+		}
+	}
+}
+
+// SetQuarantine sets the duration a key answered as invalid is not drawn.
+func (p *KeyPool) SetQuarantine(quarantine time.Duration) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.quarantine = quarantine
+}
+
+// Health returns the state of the keys of the pool, in the order they have
+// been added.
+func (p *KeyPool) Health() []KeyHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	health := make([]KeyHealth, len(p.keys))
+	for i, k := range p.keys {
+		health[i] = KeyHealth{
+			Key:      k.Key,
+			Account:  k.Account,
+			Requests: k.requests,
+			Failures: k.failures,
+			LastErr:  k.lastErr,
+		}
+		if time.Now().Before(k.quarantinedUntil) {
+			health[i].QuarantinedUntil = k.quarantinedUntil
+		}
+	}
+	return health
+}
+
+// poolCandidate is a key of the pool with a copy of its settings, taken
+// under the lock of the pool as Add may replace them.
+type poolCandidate struct {
+	key *pooledKey
+	PoolKey
+}
+
+// candidates returns the keys not quarantined, starting with the next key in
+// turn.
+func (p *KeyPool) candidates() []poolCandidate {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := time.Now()
+	candidates := make([]poolCandidate, 0, len(p.keys))
+	for i := range p.keys {
+		k := p.keys[(p.next+i)%len(p.keys)]
+		if now.Before(k.quarantinedUntil) {
+			continue
+		}
+		candidates = append(candidates, poolCandidate{key: k, PoolKey: k.PoolKey})
+	}
+	if len(p.keys) > 0 {
+		p.next = (p.next + 1) % len(p.keys)
+	}
+	return candidates
+}
+
+// account returns the account of the key, fetching it with the Requestor
+// when unknown.
+func (p *KeyPool) account(r *Requestor, c poolCandidate) (string, error) {
+	if c.Account != "" {
+		return c.Account, nil
+	}
+
+	var a Account
+	if err := r.Auth(c.Key).Account(&a).Err(); err != nil {
+		return "", err
+	}
+	p.mu.Lock()
+	if c.key.Key == c.Key && c.key.Account == "" {
+		c.key.Account = a.Name
+	}
+	p.mu.Unlock()
+	return a.Name, nil
+}
+
+// draw returns a key granting the permissions, of the account when given.
+// The keys with requests left in their budget are preferred; the budget is
+// taken by each request performed with the key, see take.
+func (p *KeyPool) draw(r *Requestor, perms []TokenPermission, account string) (poolCandidate, error) {
+	var spent poolCandidate
+	var lastErr error
+
+Candidates:
+	for _, c := range p.candidates() {
+		info, err := r.Auth(c.Key).TokenInfo()
+		if err != nil {
+			p.report(c.key, err)
+			lastErr = err
+			continue
+		}
+		for _, perm := range perms {
+			if !info.HasPermission(perm) {
+				continue Candidates
+			}
+		}
+		if account != "" {
+			name, err := p.account(r, c)
+			if err != nil {
+				p.report(c.key, err)
+				lastErr = err
+				continue
+			}
+			if name != account {
+				continue
+			}
+		}
+
+		if c.Limiter == nil || c.Limiter.available() {
+			return c, nil
+		}
+		if spent.key == nil {
+			spent = c
+		}
+	}
+
+	if spent.key != nil {
+		return spent, nil
+	}
+	if lastErr != nil {
+		return poolCandidate{}, fmt.Errorf("%w: %w", ErrNoKeyAvailable, lastErr)
+	}
+	return poolCandidate{}, ErrNoKeyAvailable
+}
+
+// take takes a request from the budget of the key, waiting or failing fast
+// depending on the RateLimitMode of the Requestor, and counts it.
+func (p *KeyPool) take(r *Requestor, k *pooledKey) error {
+	p.mu.Lock()
+	limiter := k.Limiter
+	p.mu.Unlock()
+
+	if err := r.waitLimiter(limiter); err != nil {
+		return err
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k.requests++
+	return nil
+}
+
+// report records the error of a request of the key, quarantining the key
+// when the API answered it is invalid. Only the errors caused by the key are
+// recorded, see keyFailure.
+func (p *KeyPool) report(k *pooledKey, err error) {
+	if !keyFailure(err) {
+		return
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	k.failures++
+	k.lastErr = err
+	if errors.Is(err, ErrInvalidKey) {
+		k.quarantinedUntil = time.Now().Add(p.quarantine)
+	}
+}
+
+// keyFailure reports whether the error of a request counts against the health
+// of its key: the API refused the key or rate limited it, or the request
+// could not be sent. The errors caused by the request itself, like invalid
+// ids, and the cancellation of its context are not.
+func keyFailure(err error) bool {
+	if err == nil || errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+		return false
+	}
+
+	var apiErr *APIError
+	if errors.As(err, &apiErr) {
+		switch apiErr.Kind {
+		case ErrorKindInvalidKey, ErrorKindMissingScope, ErrorKindRateLimited:
+			return true
+		}
+		return false
+	}
+	var urlErr *url.Error
+	return errors.As(err, &urlErr)
+}
+
+// KeyPool draws the API key of the authenticated requests from the pool, when
+// no key is given to Auth. Without ForAccount, any key granting the scopes of
+// the endpoint is drawn, so the account endpoints like /account/bank answer
+// with the data of any account of the pool.
+func (r *Requestor) KeyPool(pool *KeyPool) *Requestor {
+	r = r.derive()
+	r.pool = pool
+	return r
+}
+
+// ForAccount pins the keys drawn from the KeyPool to the account, like
+// "Account.1234", so the account endpoints answer with its data.
+func (r *Requestor) ForAccount(account string) *Requestor {
+	r = r.derive()
+	r.poolAccount = account
+	return r
+}
+
+// drawKey returns a copy of the Requestor sending its next requests with a
+// key of the KeyPool granting the permissions. The key is not kept by the
+// Requestors returned by those requests: each authenticated call draws its
+// own key.
+func (r *Requestor) drawKey(perms []TokenPermission) *Requestor {
+	key, err := r.pool.draw(r, perms, r.poolAccount)
+	if err != nil {
+		return r.fail(err)
+	}
+
+	r = r.derive()
+	r.poolKey = key.key
+	r.poolToken = AuthToken(key.Key)
+	return r
+}
+
+// authToken returns the token sent with the requests: the one given to Auth,
+// else the key drawn from the KeyPool.
+func (r *Requestor) authToken() AuthToken {
+	if r.token != "" {
+		return r.token
+	}
+	return r.poolToken
+}
+
+// takePoolKey takes a request from the budget of the key drawn from the
+// KeyPool, if any.
+func (r *Requestor) takePoolKey() error {
+	if r.poolKey == nil {
+		return nil
+	}
+	return r.pool.take(r, r.poolKey)
+}
+
+// releasePoolKey records the outcome of the request in the KeyPool, when
+// performed with a key drawn from it, and releases the key so the calls
+// chained on the Requestor draw their own. It must only be called on a
+// Requestor owned by the caller.
+func (r *Requestor) releasePoolKey() {
+	if r.poolKey == nil {
+		return
+	}
+	r.pool.report(r.poolKey, r.err)
+	r.poolKey = nil
+	r.poolToken = ""
+}
@@ -0,0 +1,368 @@
+package gw2api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+)
+
+// poolAccount is an account of the keys of the pool transport.
+type poolAccount struct {
+	name        string
+	permissions string
+	// True when the API answers the key is invalid.
+	revoked bool
+	// True when only the endpoints other than /tokeninfo answer the key is
+	// invalid.
+	revokedLater bool
+}
+
+// poolTransport answers the requests as the API would for the keys of the
+// accounts, and counts the requests of each key, /tokeninfo excluded.
+type poolTransport struct {
+	accounts map[string]poolAccount
+
+	mu       sync.Mutex
+	requests map[string]int
+}
+
+func newPoolTransport(accounts map[string]poolAccount) *poolTransport {
+	return &poolTransport{accounts: accounts, requests: make(map[string]int)}
+}
+
+func (p *poolTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	key := strings.TrimPrefix(req.Header.Get("Authorization"), "Bearer ")
+	account, ok := p.accounts[key]
+	tokeninfo := strings.HasSuffix(req.URL.Path, "/tokeninfo")
+	if (key != "" && !ok) || account.revoked || (account.revokedLater && !tokeninfo) {
+		return jsonResponse(req, http.StatusUnauthorized, `{"text":"Invalid access token"}`), nil
+	}
+	if tokeninfo {
+		return jsonResponse(req, http.StatusOK, `{"permissions":["`+strings.ReplaceAll(account.permissions, ",", `","`)+`"]}`), nil
+	}
+
+	p.mu.Lock()
+	p.requests[key]++
+	p.mu.Unlock()
+	if strings.HasSuffix(req.URL.Path, "/account") {
+		return jsonResponse(req, http.StatusOK, fmt.Sprintf(`{"name":%q}`, account.name)), nil
+	}
+	return jsonResponse(req, http.StatusOK, `[]`), nil
+}
+
+func (p *poolTransport) counts() map[string]int {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	counts := make(map[string]int, len(p.requests))
+	for key, count := range p.requests {
+		counts[key] = count
+	}
+	return counts
+}
+
+func TestKeyPool(t *testing.T) {
+	accounts := map[string]poolAccount{
+		"key-a": {name: "A.1234", permissions: "account,wallet"},
+		"key-b": {name: "B.5678", permissions: "account,wallet,inventories"},
+		"key-c": {name: "C.9012", permissions: "account,wallet"},
+	}
+	keys := []gw2api.PoolKey{{Key: "key-a"}, {Key: "key-b"}, {Key: "key-c"}}
+	named := []gw2api.PoolKey{{Key: "key-a", Account: "A.1234"}, {Key: "key-b", Account: "B.5678"}, {Key: "key-c", Account: "C.9012"}}
+
+	tests := []struct {
+		name string
+		keys []gw2api.PoolKey
+		call func(r *gw2api.Requestor) error
+		want map[string]int
+	}{
+		{
+			name: "spread",
+			keys: keys,
+			call: func(r *gw2api.Requestor) error {
+				var wallet []*gw2api.AccountCurrency
+				return r.AccountWallet(&wallet).Err()
+			},
+			want: map[string]int{"key-a": 2, "key-b": 2, "key-c": 2},
+		},
+		{
+			name: "scopes",
+			keys: keys,
+			call: func(r *gw2api.Requestor) error {
+				var bank []*gw2api.InventoryItem
+				return r.AccountBank(&bank).Err()
+			},
+			want: map[string]int{"key-b": 6},
+		},
+		{
+			name: "pinned",
+			keys: named,
+			call: func(r *gw2api.Requestor) error {
+				var wallet []*gw2api.AccountCurrency
+				return r.ForAccount("C.9012").AccountWallet(&wallet).Err()
+			},
+			want: map[string]int{"key-c": 6},
+		},
+		{
+			// The accounts of the keys are fetched once from /account.
+			name: "pinned without account",
+			keys: keys,
+			call: func(r *gw2api.Requestor) error {
+				var wallet []*gw2api.AccountCurrency
+				return r.ForAccount("C.9012").AccountWallet(&wallet).Err()
+			},
+			want: map[string]int{"key-a": 1, "key-b": 1, "key-c": 7},
+		},
+		{
+			name: "public",
+			keys: keys,
+			call: func(r *gw2api.Requestor) error {
+				var ids []int
+				return r.WorldIDs(&ids).Err()
+			},
+			want: map[string]int{"": 6},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newPoolTransport(accounts)
+			r := gw2api.NewRequestor().Transport(transport).KeyPool(gw2api.NewKeyPool(tt.keys...))
+			for i := 0; i < 6; i++ {
+				if err := tt.call(r); err != nil {
+					t.Fatalf("request %d = %v, want no error", i, err)
+				}
+			}
+
+			counts := transport.counts()
+			if !reflect.DeepEqual(counts, tt.want) {
+				t.Errorf("requests by key = %v, want %v", counts, tt.want)
+			}
+		})
+	}
+}
+
+func TestKeyPool_Quarantine(t *testing.T) {
+	tests := []struct {
+		name    string
+		revoked poolAccount
+	}{
+		{"invalid token info", poolAccount{name: "A.1234", permissions: "account", revoked: true}},
+		{"invalid request", poolAccount{name: "A.1234", permissions: "account", revokedLater: true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := newPoolTransport(map[string]poolAccount{
+				"key-a": tt.revoked,
+				"key-b": {name: "B.5678", permissions: "account"},
+			})
+			pool := gw2api.NewKeyPool(gw2api.PoolKey{Key: "key-a"}, gw2api.PoolKey{Key: "key-b"})
+			r := gw2api.NewRequestor().Transport(transport).KeyPool(pool)
+
+			var account gw2api.Account
+			failed := 0
+			for i := 0; i < 4; i++ {
+				if err := r.Account(&account).Err(); err != nil {
+					if !errors.Is(err, gw2api.ErrInvalidKey) {
+						t.Errorf("Requestor.Account() = %v, want %v", err, gw2api.ErrInvalidKey)
+					}
+					failed++
+				}
+			}
+			if tt.revoked.revokedLater && failed != 1 {
+				t.Errorf("%d requests failed, want only the first one", failed)
+			} else if tt.revoked.revoked && failed != 0 {
+				t.Errorf("%d requests failed, want none", failed)
+			}
+
+			health := pool.Health()
+			if health[0].QuarantinedUntil.IsZero() || health[0].Failures != 1 || !errors.Is(health[0].LastErr, gw2api.ErrInvalidKey) {
+				t.Errorf("Health() of the invalid key = %+v, want quarantined after 1 failure", health[0])
+			}
+			if !health[1].QuarantinedUntil.IsZero() || health[1].Failures != 0 {
+				t.Errorf("Health() of the valid key = %+v, want healthy", health[1])
+			}
+			if got := transport.counts()["key-b"]; got != 4-failed {
+				t.Errorf("requests of the valid key = %d, want %d", got, 4-failed)
+			}
+		})
+	}
+}
+
+func TestKeyPool_Budget(t *testing.T) {
+	transport := newPoolTransport(map[string]poolAccount{
+		"key-a": {name: "A.1234", permissions: "account"},
+		"key-b": {name: "B.5678", permissions: "account"},
+	})
+	pool := gw2api.NewKeyPool(
+		gw2api.PoolKey{Key: "key-a", Limiter: gw2api.NewRateLimiter(1, 0.001)},
+		gw2api.PoolKey{Key: "key-b", Limiter: gw2api.NewRateLimiter(2, 0.001)},
+	)
+	r := gw2api.NewRequestor().Transport(transport).KeyPool(pool).RateLimit(nil, gw2api.RateLimitFailFast)
+
+	var account gw2api.Account
+	for i := 0; i < 3; i++ {
+		if err := r.Account(&account).Err(); err != nil {
+			t.Fatalf("request %d = %v, want no error", i, err)
+		}
+	}
+	if want := map[string]int{"key-a": 1, "key-b": 2}; !reflect.DeepEqual(transport.counts(), want) {
+		t.Errorf("requests by key = %v, want %v", transport.counts(), want)
+	}
+	if err := r.Account(&account).Err(); !errors.Is(err, gw2api.ErrRateLimited) {
+		t.Errorf("Requestor.Account() with the budgets spent = %v, want %v", err, gw2api.ErrRateLimited)
+	}
+}
+
+func TestKeyPool_Chained(t *testing.T) {
+	transport := newPoolTransport(map[string]poolAccount{
+		"key-a": {name: "A.1234", permissions: "account,wallet"},
+		"key-b": {name: "B.5678", permissions: "account,inventories"},
+	})
+	pool := gw2api.NewKeyPool(
+		gw2api.PoolKey{Key: "key-a", Limiter: gw2api.NewRateLimiter(1, 0.001)},
+		gw2api.PoolKey{Key: "key-b"},
+	)
+	r := gw2api.NewRequestor().Transport(transport).KeyPool(pool).RateLimit(nil, gw2api.RateLimitFailFast)
+
+	// Each call of the chain draws a key granting its scopes.
+	var wallet []*gw2api.AccountCurrency
+	var bank []*gw2api.InventoryItem
+	var ids []int
+	if err := r.AccountWallet(&wallet).WorldIDs(&ids).AccountBank(&bank).Err(); err != nil {
+		t.Fatalf("chained calls = %v, want no error", err)
+	}
+	if want := map[string]int{"key-a": 1, "": 1, "key-b": 1}; !reflect.DeepEqual(transport.counts(), want) {
+		t.Errorf("requests by key = %v, want %v", transport.counts(), want)
+	}
+
+	// The budget of key-a is spent by the first request of the chain.
+	if err := r.AccountWallet(&wallet).AccountWallet(&wallet).Err(); !errors.Is(err, gw2api.ErrRateLimited) {
+		t.Errorf("chained calls over the budget = %v, want %v", err, gw2api.ErrRateLimited)
+	}
+	if got := pool.Health()[0].Requests; got != 1 {
+		t.Errorf("Health() requests of the key = %d, want 1", got)
+	}
+}
+
+func TestKeyPool_Concurrent(t *testing.T) {
+	accounts := map[string]poolAccount{}
+	for i := 0; i < 8; i++ {
+		accounts[fmt.Sprintf("key-%d", i)] = poolAccount{name: fmt.Sprintf("A.%d", i), permissions: "account"}
+	}
+	pool := gw2api.NewKeyPool(gw2api.PoolKey{Key: "key-0"})
+	r := gw2api.NewRequestor().Transport(newPoolTransport(accounts)).TokenInfoTTL(0).KeyPool(pool)
+
+	// Keys are added and replaced while the requests draw them, their token
+	// info fetched by each draw.
+	done := make(chan struct{})
+	added := make(chan struct{})
+	go func() {
+		defer close(added)
+		for i := 0; ; i = (i + 1) % 8 {
+			select {
+			case <-done:
+				return
+			default:
+			}
+			key := fmt.Sprintf("key-%d", i)
+			pool.Add(gw2api.PoolKey{Key: key, Limiter: gw2api.NewRateLimiter(100, 100)})
+			pool.Add(gw2api.PoolKey{Key: key, Account: fmt.Sprintf("A.%d", i)})
+		}
+	}()
+	var wg sync.WaitGroup
+	for i := 0; i < 4; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var account gw2api.Account
+			for j := 0; j < 100; j++ {
+				if err := r.Account(&account).Err(); err != nil {
+					t.Errorf("Requestor.Account() = %v, want no error", err)
+				}
+			}
+		}()
+	}
+	wg.Wait()
+	close(done)
+	<-added
+
+	requests := 0
+	for _, health := range pool.Health() {
+		requests += health.Requests
+	}
+	if requests != 400 {
+		t.Errorf("Health() counts %d requests, want 400", requests)
+	}
+}
+
+func TestKeyPool_Failures(t *testing.T) {
+	pool := newPoolTransport(map[string]poolAccount{
+		"key-a": {name: "A.1234", permissions: "account,characters"},
+	})
+	tests := []struct {
+		name         string
+		status       int
+		body         string
+		wantFailures int
+	}{
+		{"unknown id", http.StatusNotFound, `{"text":"no such id"}`, 0},
+		{"server error", http.StatusInternalServerError, `{"text":"internal error"}`, 0},
+		{"rate limited", http.StatusTooManyRequests, `{"text":"too many requests"}`, 1},
+		{"invalid key", http.StatusUnauthorized, `{"text":"Invalid access token"}`, 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				if strings.HasSuffix(req.URL.Path, "/characters") {
+					return jsonResponse(req, tt.status, tt.body), nil
+				}
+				return pool.RoundTrip(req)
+			})
+			keys := gw2api.NewKeyPool(gw2api.PoolKey{Key: "key-a"})
+			r := gw2api.NewRequestor().Transport(transport).KeyPool(keys)
+
+			var character gw2api.CharacterSummary
+			if err := r.Character(&character, "Unknown").Err(); err == nil {
+				t.Fatalf("Requestor.Character() = nil, want error")
+			}
+			if health := keys.Health()[0]; health.Failures != tt.wantFailures {
+				t.Errorf("Health().Failures = %d, want %d", health.Failures, tt.wantFailures)
+			}
+		})
+	}
+
+	keys := gw2api.NewKeyPool(gw2api.PoolKey{Key: "key-a"})
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	var account gw2api.Account
+	if err := gw2api.NewRequestor().Transport(pool).KeyPool(keys).WithContext(ctx).Account(&account).Err(); err == nil {
+		t.Fatalf("Requestor.Account() with a cancelled context = nil, want error")
+	}
+	if health := keys.Health()[0]; health.Failures != 0 {
+		t.Errorf("Health().Failures after a cancelled request = %d, want 0", health.Failures)
+	}
+}
+
+func TestKeyPool_NoKey(t *testing.T) {
+	transport := newPoolTransport(map[string]poolAccount{
+		"key-a": {name: "A.1234", permissions: "account"},
+	})
+	r := gw2api.NewRequestor().Transport(transport).KeyPool(gw2api.NewKeyPool(gw2api.PoolKey{Key: "key-a"}))
+
+	var wallet []*gw2api.AccountCurrency
+	if err := r.AccountWallet(&wallet).Err(); !errors.Is(err, gw2api.ErrNoKeyAvailable) {
+		t.Errorf("Requestor.AccountWallet() = %v, want %v", err, gw2api.ErrNoKeyAvailable)
+	}
+	if err := r.ForAccount("B.5678").AccountWallet(&wallet).Err(); !errors.Is(err, gw2api.ErrNoKeyAvailable) {
+		t.Errorf("Requestor.AccountWallet() for another account = %v, want %v", err, gw2api.ErrNoKeyAvailable)
+	}
+	if err := r.Auth("key-a").AccountWallet(&wallet).Err(); !errors.Is(err, gw2api.ErrMissingScope) {
+		t.Errorf("Requestor.AccountWallet() with a given key = %v, want %v", err, gw2api.ErrMissingScope)
+	}
+}
@@ -0,0 +1,24 @@
+package gw2api_test
+
+import (
+	"os"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+	"atomys.codes/gw2api-go/gw2apitest"
+)
+
+// server is the fake API answering the requests of the endpoint tests.
+var server *gw2apitest.Server
+
+func TestMain(m *testing.M) {
+	server = gw2apitest.NewServer()
+	code := m.Run()
+	server.Close()
+	os.Exit(code)
+}
+
+// newRequestor returns a Requestor sending its requests to the fake API.
+func newRequestor() *gw2api.Requestor {
+	return gw2api.NewRequestor().Client(server.Client())
+}
@@ -0,0 +1,227 @@
+package gw2api
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RequestMetrics are the measures of a request performed by a Requestor,
+// reported to its MetricsCollector.
+type RequestMetrics struct {
+	// The path template of the endpoint, like "/characters/:name/core", so
+	// the requests of an endpoint are measured together whatever their ids.
+	Endpoint string
+	// The HTTP status of the response, 0 when no response has been received.
+	StatusCode int
+	// True when the request failed.
+	Failed bool
+	// The time taken by the request, retries included.
+	Duration time.Duration
+	// The amount of attempts answered with 429 Too Many Requests.
+	RateLimited int
+	// True when the response has been served from the cache.
+	Cached bool
+	// The size of the body decoded, in bytes.
+	BytesDecoded int
+}
+
+// MetricsCollector collects the metrics of the requests performed by a
+// Requestor. It must be safe for concurrent use.
+type MetricsCollector interface {
+	// ObserveRequest is called once per request, once it is done.
+	ObserveRequest(metrics RequestMetrics)
+}
+
+// Metrics reports the metrics of the requests performed by the Requestor to
+// the collector. The same collector can be given to several Requestors.
+// Give a nil collector to disable the metrics.
+func (r *Requestor) Metrics(collector MetricsCollector) *Requestor {
+	r = r.derive()
+	r.metrics = collector
+	return r
+}
+
+func newRequestMetrics(info *RequestInfo) RequestMetrics {
+	return RequestMetrics{
+		Endpoint:     endpointTemplate(info.Endpoint),
+		StatusCode:   info.StatusCode,
+		Failed:       info.Err != nil,
+		Duration:     info.Duration,
+		RateLimited:  info.RateLimited,
+		Cached:       info.Cached,
+		BytesDecoded: info.BytesDecoded,
+	}
+}
+
+// DefaultLatencyBuckets are the upper bounds, in seconds, of the latency
+// histogram buckets of a MemoryMetrics.
+var DefaultLatencyBuckets = []float64{.005, .01, .025, .05, .1, .25, .5, 1, 2.5, 5, 10}
+
+// MemoryMetrics is a MetricsCollector keeping the metrics in memory, by
+// endpoint path template. It renders them in the Prometheus text exposition
+// format, and can be served as is by an http.Handler:
+//
+//	metrics := gw2api.NewMemoryMetrics()
+//	r := gw2api.NewRequestor().Metrics(metrics)
+//	http.Handle("/metrics", metrics)
+type MemoryMetrics struct {
+	buckets []float64
+
+	mu        sync.Mutex
+	endpoints map[string]*endpointMetrics
+}
+
+type endpointMetrics struct {
+	requests     map[string]uint64
+	failures     uint64
+	rateLimited  uint64
+	cacheHits    uint64
+	cacheMisses  uint64
+	bytesDecoded uint64
+	buckets      []uint64
+	durationSum  float64
+	count        uint64
+}
+
+// NewMemoryMetrics returns an empty MemoryMetrics, measuring the latencies
+// with the given histogram buckets, in seconds. DefaultLatencyBuckets are
+// used when none is given.
+func NewMemoryMetrics(buckets ...float64) *MemoryMetrics {
+	if len(buckets) == 0 {
+		buckets = DefaultLatencyBuckets
+	}
+	buckets = append([]float64(nil), buckets...)
+	sort.Float64s(buckets)
+
+	return &MemoryMetrics{
+		buckets:   buckets,
+		endpoints: make(map[string]*endpointMetrics),
+	}
+}
+
+// ObserveRequest implements MetricsCollector.
+func (m *MemoryMetrics) ObserveRequest(metrics RequestMetrics) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.endpoints[metrics.Endpoint]
+	if !ok {
+		e = &endpointMetrics{
+			requests: make(map[string]uint64),
+			buckets:  make([]uint64, len(m.buckets)),
+		}
+		m.endpoints[metrics.Endpoint] = e
+	}
+
+	status := "none"
+	if metrics.StatusCode != 0 {
+		status = strconv.Itoa(metrics.StatusCode)
+	}
+	e.requests[status]++
+	if metrics.Failed {
+		e.failures++
+	}
+	e.rateLimited += uint64(metrics.RateLimited)
+	if metrics.Cached {
+		e.cacheHits++
+	} else {
+		e.cacheMisses++
+	}
+	e.bytesDecoded += uint64(metrics.BytesDecoded)
+
+	seconds := metrics.Duration.Seconds()
+	for i, bound := range m.buckets {
+		if seconds <= bound {
+			e.buckets[i]++
+		}
+	}
+	e.durationSum += seconds
+	e.count++
+}
+
+// CacheHitRatio returns the ratio of the requests of the endpoint served from
+// the cache, between 0 and 1.
+func (m *MemoryMetrics) CacheHitRatio(endpoint string) float64 {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.endpoints[endpoint]
+	if !ok || e.count == 0 {
+		return 0
+	}
+	return float64(e.cacheHits) / float64(e.count)
+}
+
+// WritePrometheus writes the metrics in the Prometheus text exposition format.
+func (m *MemoryMetrics) WritePrometheus(w io.Writer) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	endpoints := make([]string, 0, len(m.endpoints))
+	for endpoint := range m.endpoints {
+		endpoints = append(endpoints, endpoint)
+	}
+	sort.Strings(endpoints)
+
+	bw := bufio.NewWriter(w)
+	counter := func(name, help string, value func(e *endpointMetrics) uint64) {
+		fmt.Fprintf(bw, "# HELP %s %s\n# TYPE %s counter\n", name, help, name)
+		for _, endpoint := range endpoints {
+			fmt.Fprintf(bw, "%s{endpoint=%s} %d\n", name, labelValue(endpoint), value(m.endpoints[endpoint]))
+		}
+	}
+
+	fmt.Fprintf(bw, "# HELP gw2api_requests_total Requests performed, by endpoint and HTTP status.\n# TYPE gw2api_requests_total counter\n")
+	for _, endpoint := range endpoints {
+		e := m.endpoints[endpoint]
+		statuses := make([]string, 0, len(e.requests))
+		for status := range e.requests {
+			statuses = append(statuses, status)
+		}
+		sort.Strings(statuses)
+		for _, status := range statuses {
+			fmt.Fprintf(bw, "gw2api_requests_total{endpoint=%s,status=%s} %d\n", labelValue(endpoint), labelValue(status), e.requests[status])
+		}
+	}
+
+	counter("gw2api_request_failures_total", "Requests which failed.", func(e *endpointMetrics) uint64 { return e.failures })
+	counter("gw2api_rate_limited_total", "Attempts answered with 429 Too Many Requests.", func(e *endpointMetrics) uint64 { return e.rateLimited })
+	counter("gw2api_cache_hits_total", "Requests served from the cache.", func(e *endpointMetrics) uint64 { return e.cacheHits })
+	counter("gw2api_cache_misses_total", "Requests not served from the cache.", func(e *endpointMetrics) uint64 { return e.cacheMisses })
+	counter("gw2api_decoded_bytes_total", "Bytes of response bodies decoded.", func(e *endpointMetrics) uint64 { return e.bytesDecoded })
+
+	fmt.Fprintf(bw, "# HELP gw2api_request_duration_seconds Duration of the requests, retries included.\n# TYPE gw2api_request_duration_seconds histogram\n")
+	for _, endpoint := range endpoints {
+		e := m.endpoints[endpoint]
+		label := labelValue(endpoint)
+		for i, bound := range m.buckets {
+			fmt.Fprintf(bw, "gw2api_request_duration_seconds_bucket{endpoint=%s,le=\"%s\"} %d\n", label, strconv.FormatFloat(bound, 'g', -1, 64), e.buckets[i])
+		}
+		fmt.Fprintf(bw, "gw2api_request_duration_seconds_bucket{endpoint=%s,le=\"+Inf\"} %d\n", label, e.count)
+		fmt.Fprintf(bw, "gw2api_request_duration_seconds_sum{endpoint=%s} %s\n", label, strconv.FormatFloat(e.durationSum, 'g', -1, 64))
+		fmt.Fprintf(bw, "gw2api_request_duration_seconds_count{endpoint=%s} %d\n", label, e.count)
+	}
+
+	return bw.Flush()
+}
+
+// ServeHTTP serves the metrics in the Prometheus text exposition format.
+func (m *MemoryMetrics) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	w.Header().Set("Content-Type", "text/plain; version=0.0.4; charset=utf-8")
+	_ = m.WritePrometheus(w)
+}
+
+var labelReplacer = strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`)
+
+// labelValue returns the quoted label value, escaped for the text exposition
+// format.
+func labelValue(value string) string {
+	return `"` + labelReplacer.Replace(value) + `"`
+}
@@ -0,0 +1,121 @@
+package gw2api_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestMemoryMetrics(t *testing.T) {
+	const world = `{"id":2101,"name":"Jade Sea [FR]"}`
+	var mu sync.Mutex
+	worldCalls := 0
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		switch {
+		case strings.HasSuffix(req.URL.Path, "/tokeninfo"):
+			return jsonResponse(req, http.StatusOK, `{"permissions":["account","characters","builds","guilds"]}`), nil
+		case strings.HasSuffix(req.URL.Path, "/worlds"):
+			mu.Lock()
+			defer mu.Unlock()
+			worldCalls++
+			if worldCalls == 1 {
+				return jsonResponse(req, http.StatusTooManyRequests, `{"text":"too many requests"}`), nil
+			}
+			response := jsonResponse(req, http.StatusOK, world)
+			response.Header.Set("Cache-Control", "max-age=60")
+			return response, nil
+		case strings.HasSuffix(req.URL.Path, "/permissions"):
+			return jsonResponse(req, http.StatusOK, `["Admin"]`), nil
+		default:
+			return jsonResponse(req, http.StatusOK, `{}`), nil
+		}
+	})
+
+	metrics := gw2api.NewMemoryMetrics()
+	r := gw2api.NewRequestor().
+		Transport(transport).
+		Metrics(metrics).
+		Cache(gw2api.NewMemoryCache()).
+		Retry(gw2api.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}).
+		Auth("api-key")
+
+	var w gw2api.World
+	var character gw2api.Character
+	var tab gw2api.CharacterBuildTab
+	var guild gw2api.Guild
+	var permissions []string
+	for _, requestor := range []*gw2api.Requestor{
+		r.World(&w, 2101),
+		r.World(&w, 2101),
+		r.CharacterCore(&character, "Fake Character"),
+		r.CharacterCore(&character, "Other Character"),
+		r.CharacterBuildTab(&tab, "Fake Character", 2),
+		r.CharacterActiveBuildTab(&tab, "Fake Character"),
+		r.Guild(&guild, "116E0C0E-0035-44A9-BB22-4AE3E23127E5"),
+		r.GuildPermissionIDs(&permissions),
+	} {
+		if err := requestor.Err(); err != nil {
+			t.Fatalf("request failed: %v", err)
+		}
+	}
+
+	if got := metrics.CacheHitRatio("/worlds"); got != 0.5 {
+		t.Errorf("MemoryMetrics.CacheHitRatio(/worlds) = %v, want 0.5", got)
+	}
+
+	var text strings.Builder
+	if err := metrics.WritePrometheus(&text); err != nil {
+		t.Fatalf("MemoryMetrics.WritePrometheus() = %v, want no error", err)
+	}
+	for _, want := range []string{
+		`gw2api_requests_total{endpoint="/tokeninfo",status="200"} 1`,
+		`gw2api_requests_total{endpoint="/worlds",status="200"} 2`,
+		`gw2api_requests_total{endpoint="/characters/:name/core",status="200"} 2`,
+		`gw2api_requests_total{endpoint="/characters/:name/buildtabs/:tab",status="200"} 1`,
+		`gw2api_requests_total{endpoint="/characters/:name/buildtabs/active",status="200"} 1`,
+		`gw2api_requests_total{endpoint="/guild/:id",status="200"} 1`,
+		`gw2api_requests_total{endpoint="/guild/permissions",status="200"} 1`,
+		`gw2api_rate_limited_total{endpoint="/worlds"} 1`,
+		`gw2api_cache_hits_total{endpoint="/worlds"} 1`,
+		`gw2api_cache_misses_total{endpoint="/worlds"} 1`,
+		`gw2api_decoded_bytes_total{endpoint="/worlds"} 68`,
+		`gw2api_request_failures_total{endpoint="/worlds"} 0`,
+		`gw2api_request_duration_seconds_bucket{endpoint="/worlds",le="+Inf"} 2`,
+		`gw2api_request_duration_seconds_count{endpoint="/worlds"} 2`,
+		"# TYPE gw2api_request_duration_seconds histogram",
+	} {
+		if !strings.Contains(text.String(), want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, text.String())
+		}
+	}
+	if strings.Contains(text.String(), "Fake Character") {
+		t.Errorf("metrics are labeled by raw path:\n%s", text.String())
+	}
+}
+
+func TestMemoryMetrics_ServeHTTP(t *testing.T) {
+	metrics := gw2api.NewMemoryMetrics(1, 0.1)
+	metrics.ObserveRequest(gw2api.RequestMetrics{Endpoint: `/odd"endpoint`, Failed: true, Duration: 50 * time.Millisecond})
+
+	recorder := httptest.NewRecorder()
+	metrics.ServeHTTP(recorder, httptest.NewRequest(http.MethodGet, "/metrics", nil))
+
+	if got := recorder.Header().Get("Content-Type"); !strings.HasPrefix(got, "text/plain; version=0.0.4") {
+		t.Errorf("Content-Type = %q, want the text exposition format", got)
+	}
+	for _, want := range []string{
+		`gw2api_requests_total{endpoint="/odd\"endpoint",status="none"} 1`,
+		`gw2api_request_failures_total{endpoint="/odd\"endpoint"} 1`,
+		`gw2api_request_duration_seconds_bucket{endpoint="/odd\"endpoint",le="0.1"} 1`,
+		`gw2api_request_duration_seconds_bucket{endpoint="/odd\"endpoint",le="1"} 1`,
+	} {
+		if !strings.Contains(recorder.Body.String(), want) {
+			t.Errorf("metrics do not contain %q:\n%s", want, recorder.Body.String())
+		}
+	}
+}
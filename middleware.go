@@ -0,0 +1,160 @@
+package gw2api
+
+import (
+	"context"
+	"log/slog"
+	"net/http"
+	"net/url"
+	"time"
+)
+
+// RequestInfo describes a request performed by a Requestor to its
+// Middlewares.
+type RequestInfo struct {
+	// The endpoint requested, like "/worlds".
+	Endpoint string
+	// The query parameters of the request.
+	Query url.Values
+	// The HTTP request sent to the API. Its headers can be modified by the
+	// Before hooks.
+	Request *http.Request
+	// The number of the attempt, starting at 1. After the request, the amount
+	// of attempts done, 0 when served from the cache of the Requestor.
+	Attempt int
+
+	// The fields below are only set for the After hooks.
+
+	// The time taken by the request, retries included.
+	Duration time.Duration
+	// The HTTP status of the response, 0 when no response has been received.
+	StatusCode int
+	// True when the response has been served from the cache, either without
+	// reaching the API or after a 304 Not Modified.
+	Cached bool
+	// The amount of attempts answered with 429 Too Many Requests.
+	RateLimited int
+	// The size of the body decoded, in bytes.
+	BytesDecoded int
+	// The error of the request, nil when it succeeded.
+	Err error
+	// The error of the decoding of the response, also returned by Err.
+	DecodeErr error
+}
+
+// Middleware hooks into the requests performed by a Requestor, to log, trace
+// or modify them. Either hook can be nil.
+type Middleware struct {
+	// Before is called before sending each attempt of a request. Returning
+	// a non-nil response, with its Body set, short-circuits the request: the
+	// response is decoded as if it was received from the API, without sending
+	// the request.
+	Before func(info *RequestInfo) *http.Response
+	// After is called once the request is done, successful or not.
+	After func(info *RequestInfo)
+}
+
+// Use adds middlewares to the Requestor. The Before hooks are called in the
+// order of the middlewares, the first response returned short-circuiting the
+// request, and the After hooks in the reverse order.
+func (r *Requestor) Use(middlewares ...Middleware) *Requestor {
+	r = r.derive()
+	r.middlewares = append(r.middlewares[:len(r.middlewares):len(r.middlewares)], middlewares...)
+	return r
+}
+
+// before calls the Before hooks of the middlewares, returning the response
+// short-circuiting the request, if any.
+func (r *Requestor) before(info *RequestInfo) *http.Response {
+	for _, m := range r.middlewares {
+		if m.Before == nil {
+			continue
+		}
+		if response := m.Before(info); response != nil {
+			return response
+		}
+	}
+	return nil
+}
+
+// after completes the info with the outcome of the request, reports it to the
+// MetricsCollector, then calls the After hooks of the middlewares.
+func (r *Requestor) after(info *RequestInfo, start time.Time) {
+	if len(r.middlewares) == 0 && r.metrics == nil {
+		return
+	}
+
+	info.Duration = time.Since(start)
+	info.Err = r.err
+	if r.response != nil {
+		info.StatusCode = r.response.StatusCode
+		info.Cached = r.response.Cached
+	}
+	if r.metrics != nil {
+		r.metrics.ObserveRequest(newRequestMetrics(info))
+	}
+
+	for i := len(r.middlewares) - 1; i >= 0; i-- {
+		if after := r.middlewares[i].After; after != nil {
+			after(info)
+		}
+	}
+}
+
+// LoggingMiddleware returns a Middleware logging each request with the
+// logger: its endpoint, query, headers, attempts, duration, status and error.
+// The Authorization header and the access_token parameter are redacted.
+// Successful requests are logged at the Info level, failed ones at the Error
+// level.
+func LoggingMiddleware(logger *slog.Logger) Middleware {
+	return Middleware{
+		After: func(info *RequestInfo) {
+			level := slog.LevelInfo
+			attrs := []slog.Attr{
+				slog.String("endpoint", info.Endpoint),
+				slog.String("query", redactQuery(info.Query).Encode()),
+				slog.Int("attempts", info.Attempt),
+				slog.Duration("duration", info.Duration),
+				slog.Int("status", info.StatusCode),
+			}
+			if info.Request != nil {
+				attrs = append(attrs, slog.Any("header", redactHeader(info.Request.Header)))
+			}
+			if info.Err != nil {
+				level = slog.LevelError
+				attrs = append(attrs, slog.String("error", info.Err.Error()))
+			}
+			if info.DecodeErr != nil {
+				attrs = append(attrs, slog.String("decode_error", info.DecodeErr.Error()))
+			}
+
+			ctx := context.Background()
+			if info.Request != nil {
+				ctx = info.Request.Context()
+			}
+			logger.LogAttrs(ctx, level, "gw2api request", attrs...)
+		},
+	}
+}
+
+const redacted = "REDACTED"
+
+func redactHeader(header http.Header) http.Header {
+	header = header.Clone()
+	if header.Get("Authorization") != "" {
+		header.Set("Authorization", redacted)
+	}
+	return header
+}
+
+func redactQuery(query url.Values) url.Values {
+	if query.Get("access_token") == "" {
+		return query
+	}
+
+	redactedQuery := make(url.Values, len(query))
+	for key, values := range query {
+		redactedQuery[key] = values
+	}
+	redactedQuery.Set("access_token", redacted)
+	return redactedQuery
+}
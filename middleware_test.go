@@ -0,0 +1,198 @@
+package gw2api_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"io"
+	"log/slog"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestMiddleware(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		body         string
+		wantAttempts []int
+		wantStatus   int
+		wantErr      bool
+		wantDecode   bool
+	}{
+		{"success", []int{http.StatusOK}, `{"id":2101}`, []int{1}, http.StatusOK, false, false},
+		{"retried", []int{http.StatusServiceUnavailable, http.StatusOK}, `{"id":2101}`, []int{1, 2}, http.StatusOK, false, false},
+		{"api error", []int{http.StatusNotFound}, `{"text":"no such id"}`, []int{1}, http.StatusNotFound, true, false},
+		{"decode error", []int{http.StatusOK}, `{"id":"2101"}`, []int{1}, http.StatusOK, true, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			calls := 0
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				status := tt.statuses[calls]
+				calls++
+				return jsonResponse(req, status, tt.body), nil
+			})
+
+			var attempts []int
+			var after *gw2api.RequestInfo
+			middleware := gw2api.Middleware{
+				Before: func(info *gw2api.RequestInfo) *http.Response {
+					attempts = append(attempts, info.Attempt)
+					return nil
+				},
+				After: func(info *gw2api.RequestInfo) {
+					after = info
+				},
+			}
+			policy := gw2api.RetryPolicy{MaxAttempts: 2, BaseDelay: time.Millisecond}
+
+			var world gw2api.World
+			err := gw2api.NewRequestor().Transport(transport).Retry(policy).Use(middleware).World(&world, 2101).Err()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Requestor.World() = %v, want error %v", err, tt.wantErr)
+			}
+
+			if !reflect.DeepEqual(attempts, tt.wantAttempts) {
+				t.Errorf("Before called with attempts %v, want %v", attempts, tt.wantAttempts)
+			}
+			if after == nil {
+				t.Fatalf("After not called")
+			}
+			if after.Endpoint != "/worlds" || after.Query.Get("id") != "2101" {
+				t.Errorf("After called with %s?%s, want /worlds?id=2101", after.Endpoint, after.Query.Encode())
+			}
+			if after.Attempt != len(tt.wantAttempts) {
+				t.Errorf("After called with attempt %d, want %d", after.Attempt, len(tt.wantAttempts))
+			}
+			if after.StatusCode != tt.wantStatus {
+				t.Errorf("After called with status %d, want %d", after.StatusCode, tt.wantStatus)
+			}
+			if after.Duration <= 0 {
+				t.Errorf("After called with duration %v, want > 0", after.Duration)
+			}
+			if after.Err != err {
+				t.Errorf("After called with error %v, want %v", after.Err, err)
+			}
+			if (after.DecodeErr != nil) != tt.wantDecode {
+				t.Errorf("After called with decode error %v, want error %v", after.DecodeErr, tt.wantDecode)
+			}
+		})
+	}
+}
+
+func TestMiddleware_ShortCircuit(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		t.Errorf("request sent to the API")
+		return nil, errors.New("unexpected request")
+	})
+	cached := gw2api.Middleware{
+		Before: func(info *gw2api.RequestInfo) *http.Response {
+			return jsonResponse(info.Request, http.StatusOK, `{"id":2101,"name":"Jade Sea [FR]"}`)
+		},
+	}
+
+	var world gw2api.World
+	if err := gw2api.NewRequestor().Transport(transport).Use(cached).World(&world, 2101).Err(); err != nil {
+		t.Fatalf("Requestor.World() = %v, want no error", err)
+	}
+	if world.Name != "Jade Sea [FR]" {
+		t.Errorf("World.Name = %q, want %q", world.Name, "Jade Sea [FR]")
+	}
+}
+
+func TestMiddleware_Order(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusOK, `[2101]`), nil
+	})
+
+	var calls []string
+	middleware := func(name string) gw2api.Middleware {
+		return gw2api.Middleware{
+			Before: func(info *gw2api.RequestInfo) *http.Response {
+				calls = append(calls, "before "+name)
+				return nil
+			},
+			After: func(info *gw2api.RequestInfo) {
+				calls = append(calls, "after "+name)
+			},
+		}
+	}
+
+	r := gw2api.NewRequestor().Transport(transport).Use(middleware("first"))
+	var ids []int
+	if err := r.Use(middleware("second")).WorldIDs(&ids).Err(); err != nil {
+		t.Fatalf("Requestor.WorldIDs() = %v, want no error", err)
+	}
+	if want := "before first, before second, after second, after first"; strings.Join(calls, ", ") != want {
+		t.Errorf("hooks called in order %v, want %s", calls, want)
+	}
+
+	calls = nil
+	if err := r.WorldIDs(&ids).Err(); err != nil {
+		t.Fatalf("Requestor.WorldIDs() = %v, want no error", err)
+	}
+	if want := "before first, after first"; strings.Join(calls, ", ") != want {
+		t.Errorf("hooks of the parent Requestor called in order %v, want %s", calls, want)
+	}
+}
+
+func TestLoggingMiddleware(t *testing.T) {
+	const apiKey = "secret-api-key"
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/tokeninfo") {
+			return jsonResponse(req, http.StatusOK, `{"permissions":["account"]}`), nil
+		}
+		return jsonResponse(req, http.StatusUnauthorized, `{"text":"Invalid access token"}`), nil
+	})
+
+	var logs bytes.Buffer
+	logger := slog.New(slog.NewJSONHandler(&logs, nil))
+	r := gw2api.NewRequestor().Transport(transport).Use(gw2api.LoggingMiddleware(logger)).Auth(apiKey)
+
+	var account gw2api.Account
+	if err := r.Account(&account).Err(); err == nil {
+		t.Fatalf("Requestor.Account() = nil, want error")
+	}
+	if strings.Contains(logs.String(), apiKey) {
+		t.Errorf("logs contain the API key:\n%s", logs.String())
+	}
+
+	var records []map[string]interface{}
+	decoder := json.NewDecoder(&logs)
+	for {
+		var record map[string]interface{}
+		if err := decoder.Decode(&record); err == io.EOF {
+			break
+		} else if err != nil {
+			t.Fatal(err)
+		}
+		records = append(records, record)
+	}
+	if len(records) != 2 {
+		t.Fatalf("logged %d records, want 2", len(records))
+	}
+
+	tests := []struct {
+		endpoint  string
+		wantLevel string
+	}{
+		{"/tokeninfo", "INFO"},
+		{"/account", "ERROR"},
+	}
+	for i, tt := range tests {
+		record := records[i]
+		if record["endpoint"] != tt.endpoint || record["level"] != tt.wantLevel {
+			t.Errorf("record %d = %v %v, want %s %s", i, record["level"], record["endpoint"], tt.wantLevel, tt.endpoint)
+		}
+		header, _ := record["header"].(map[string]interface{})
+		if authorization, _ := header["Authorization"].([]interface{}); len(authorization) != 1 || authorization[0] != "REDACTED" {
+			t.Errorf("record %d Authorization = %v, want REDACTED", i, header["Authorization"])
+		}
+	}
+}
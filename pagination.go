@@ -0,0 +1,154 @@
+package gw2api
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"strconv"
+)
+
+const (
+	// DefaultPageSize is the page size used by the API when none is given.
+	DefaultPageSize = 50
+	// MaxPageSize is the maximum page size accepted by the API.
+	MaxPageSize = 200
+)
+
+// Paginator fetches the pages of a paginated endpoint using the `page` and
+// `page_size` parameters. The totals reported by the API in the
+// X-Page-Total, X-Page-Size and X-Result-Total headers are exposed after
+// each fetched page.
+// Pages are numbered from 0.
+type Paginator struct {
+	requestor   *Requestor
+	endpoint    string
+	pageSize    int
+	next        int
+	pageTotal   int
+	resultTotal int
+	err         error
+}
+
+// paginator returns a Paginator over the endpoint, checking the scopes it
+// requires. Page sizes out of the range accepted by the API are replaced by
+// the closest valid one.
+func (r *Requestor) paginator(endpoint string, pageSize int) *Paginator {
+	r = r.authorizeEndpoint(endpoint)
+	if pageSize < 1 {
+		pageSize = DefaultPageSize
+	} else if pageSize > MaxPageSize {
+		pageSize = MaxPageSize
+	}
+
+	requestor := r.derive()
+	requestor.err = nil
+
+	return &Paginator{
+		requestor: requestor,
+		endpoint:  endpoint,
+		pageSize:  pageSize,
+		pageTotal: -1,
+		err:       r.err,
+	}
+}
+
+// Page fetches the given page into the pointer, a pointer to a slice.
+func (p *Paginator) Page(pointer interface{}, page int) error {
+	if p.err != nil {
+		return p.err
+	}
+
+	requestor := p.requestor.request(p.endpoint, url.Values{
+		"page":      []string{strconv.Itoa(page)},
+		"page_size": []string{strconv.Itoa(p.pageSize)},
+	}, &pointer)
+	if requestor.err != nil {
+		return requestor.err
+	}
+
+	p.readTotals(requestor.response.Header, page)
+	p.next = page + 1
+	return nil
+}
+
+// Next fetches the page following the last fetched one into the pointer,
+// starting at the first page. It returns false when all the pages have been
+// fetched or an error occurred, reported by Err.
+func (p *Paginator) Next(pointer interface{}) bool {
+	if p.err != nil || !p.HasNext() {
+		return false
+	}
+
+	if err := p.Page(pointer, p.next); err != nil {
+		p.err = err
+		return false
+	}
+	return true
+}
+
+// HasNext reports whether a page remains to be fetched by Next.
+func (p *Paginator) HasNext() bool {
+	return p.pageTotal < 0 || p.next < p.pageTotal
+}
+
+// All fetches every remaining page and appends their objects to the slice
+// the pointer points to.
+func (p *Paginator) All(pointer interface{}) error {
+	slice := sliceOf(pointer)
+	if !slice.IsValid() {
+		return fmt.Errorf("cannot append pages of %s into %T", p.endpoint, pointer)
+	}
+
+	for p.HasNext() {
+		page := reflect.New(slice.Type())
+		if !p.Next(page.Interface()) {
+			break
+		}
+		slice.Set(reflect.AppendSlice(slice, page.Elem()))
+	}
+	return p.err
+}
+
+// Err returns the error which stopped Next, if any.
+func (p *Paginator) Err() error {
+	return p.err
+}
+
+// PageSize returns the size of the pages, as reported by the API once a page
+// has been fetched.
+func (p *Paginator) PageSize() int {
+	return p.pageSize
+}
+
+// PageTotal returns the total amount of pages, or -1 before the first
+// fetched page.
+func (p *Paginator) PageTotal() int {
+	return p.pageTotal
+}
+
+// ResultTotal returns the total amount of objects across all the pages.
+func (p *Paginator) ResultTotal() int {
+	return p.resultTotal
+}
+
+// readTotals updates the totals of the Paginator from the headers of a page.
+// Without X-Page-Total header, the page is considered as the last one.
+func (p *Paginator) readTotals(header http.Header, page int) {
+	total := func(name string) (int, bool) {
+		n, err := strconv.Atoi(header.Get(name))
+		return n, err == nil
+	}
+
+	p.pageTotal = page + 1
+
+	if n, ok := total("X-Page-Total"); ok {
+		p.pageTotal = n
+	}
+	if n, ok := total("X-Page-Size"); ok {
+		p.pageSize = n
+	}
+	if n, ok := total("X-Result-Total"); ok {
+		p.resultTotal = n
+	}
+}
@@ -0,0 +1,116 @@
+package gw2api_test
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+)
+
+// pagesTransport serves `results` objects by pages, as the API does.
+func pagesTransport(results int) roundTripFunc {
+	return func(req *http.Request) (*http.Response, error) {
+		page, _ := strconv.Atoi(req.URL.Query().Get("page"))
+		size, _ := strconv.Atoi(req.URL.Query().Get("page_size"))
+		total := (results + size - 1) / size
+		if page >= total {
+			return jsonResponse(req, http.StatusBadRequest, `{"text":"page out of range. Use values 0 - 1."}`), nil
+		}
+
+		var objects []string
+		for id := page * size; id < (page+1)*size && id < results; id++ {
+			objects = append(objects, fmt.Sprintf(`{"id":%d}`, id))
+		}
+
+		response := jsonResponse(req, http.StatusOK, "["+strings.Join(objects, ",")+"]")
+		response.Header.Set("X-Page-Total", strconv.Itoa(total))
+		response.Header.Set("X-Page-Size", strconv.Itoa(size))
+		response.Header.Set("X-Result-Total", strconv.Itoa(results))
+		return response, nil
+	}
+}
+
+func TestPaginator_Page(t *testing.T) {
+	tests := []struct {
+		name    string
+		page    int
+		wantIDs []int
+		wantErr bool
+	}{
+		{"first page", 0, []int{0, 1}, false},
+		{"last page", 2, []int{4}, false},
+		{"page out of range", 3, nil, true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			paginator := gw2api.NewRequestor().Transport(pagesTransport(5)).AchievementsPaginator(2)
+
+			var achievements []*gw2api.Achievement
+			err := paginator.Page(&achievements, tt.page)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Paginator.Page() = %v, want error %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+
+			if len(achievements) != len(tt.wantIDs) {
+				t.Fatalf("Paginator.Page() returned %d achievements, want %d", len(achievements), len(tt.wantIDs))
+			}
+			for i, id := range tt.wantIDs {
+				if achievements[i].ID != id {
+					t.Errorf("Paginator.Page()[%d].ID = %d, want %d", i, achievements[i].ID, id)
+				}
+			}
+			if paginator.PageTotal() != 3 || paginator.ResultTotal() != 5 || paginator.PageSize() != 2 {
+				t.Errorf("Paginator totals = %d pages, %d results, size %d, want 3, 5, 2",
+					paginator.PageTotal(), paginator.ResultTotal(), paginator.PageSize())
+			}
+		})
+	}
+}
+
+func TestPaginator_Next(t *testing.T) {
+	paginator := gw2api.NewRequestor().Transport(pagesTransport(5)).CommerceListingsPaginator(2)
+
+	var pages, results int
+	var listings []*gw2api.CommerceListings
+	for paginator.Next(&listings) {
+		pages++
+		results += len(listings)
+	}
+	if err := paginator.Err(); err != nil {
+		t.Fatalf("Paginator.Err() = %v, want no error", err)
+	}
+	if pages != 3 || results != 5 {
+		t.Errorf("Paginator.Next() fetched %d pages and %d results, want 3 and 5", pages, results)
+	}
+}
+
+func TestPaginator_All(t *testing.T) {
+	paginator := gw2api.NewRequestor().Transport(pagesTransport(450)).CommercePricesPaginator(gw2api.MaxPageSize)
+
+	var prices []*gw2api.CommercePrices
+	if err := paginator.All(&prices); err != nil {
+		t.Fatalf("Paginator.All() = %v, want no error", err)
+	}
+	if len(prices) != 450 {
+		t.Fatalf("Paginator.All() returned %d prices, want 450", len(prices))
+	}
+	for i, price := range prices {
+		if price.ID != i {
+			t.Fatalf("Paginator.All()[%d].ID = %d, want %d", i, price.ID, i)
+		}
+	}
+}
+
+func TestPaginator_WithoutAuth(t *testing.T) {
+	var transactions []*gw2api.CommerceTransaction
+	err := gw2api.NewRequestor().CommerceTransactionsHistoryBuysPaginator(50).All(&transactions)
+	if err != gw2api.ErrRequireAuthentication {
+		t.Errorf("Paginator.All() = %v, want %v", err, gw2api.ErrRequireAuthentication)
+	}
+}
@@ -1,5 +1,12 @@
 package gw2api
 
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
 type TokenPermission uint
 
 const (
@@ -30,12 +37,171 @@ var (
 		"unlocks":     TokenPermissionUnlocks,
 		"wallet":      TokenPermissionWallet,
 	}
+	permissionNames = func() map[TokenPermission]string {
+		names := make(map[TokenPermission]string, len(permissionsMapping))
+		for name, perm := range permissionsMapping {
+			names[perm] = name
+		}
+		return names
+	}()
 )
 
-func getBitwise(n, pos uint) bool {
-	return (n>>pos)&1 == 1
+// String returns the name of the permission in the API, like "account".
+func (p TokenPermission) String() string {
+	if name, ok := permissionNames[p]; ok {
+		return name
+	}
+	return fmt.Sprintf("TokenPermission(%d)", uint(p))
+}
+
+// ParseTokenPermission returns the permission named by the API, reporting
+// false when the name is unknown to the package.
+func ParseTokenPermission(name string) (TokenPermission, bool) {
+	perm, ok := permissionsMapping[name]
+	return perm, ok
+}
+
+// PermissionSet is a set of permissions, kept by name so the permissions
+// added to the API after this package are kept too, see Unknown. The zero
+// value is an empty set. A PermissionSet is never modified, its operations
+// return a new set.
+type PermissionSet struct {
+	// The sorted names of the permissions, without duplicates.
+	names []string
+}
+
+// NewPermissionSet returns the set of the permissions.
+func NewPermissionSet(perms ...TokenPermission) PermissionSet {
+	names := make([]string, 0, len(perms))
+	for _, perm := range perms {
+		names = append(names, perm.String())
+	}
+	return newPermissionSet(names)
+}
+
+// ParsePermissionSet returns the set of the comma separated permission
+// names, as given to CreateSubToken: "account,wallet". Unknown names are
+// kept.
+func ParsePermissionSet(s string) PermissionSet {
+	return newPermissionSet(strings.Split(s, ","))
+}
+
+// newPermissionSet returns the set of the names, trimmed, sorted and without
+// duplicates or empty names.
+func newPermissionSet(names []string) PermissionSet {
+	set := make([]string, 0, len(names))
+	for _, name := range names {
+		if name = strings.TrimSpace(name); name != "" {
+			set = append(set, name)
+		}
+	}
+	sort.Strings(set)
+
+	unique := set[:0]
+	for i, name := range set {
+		if i == 0 || name != set[i-1] {
+			unique = append(unique, name)
+		}
+	}
+	return PermissionSet{names: unique}
+}
+
+// Len returns the amount of permissions in the set, unknown ones included.
+func (s PermissionSet) Len() int {
+	return len(s.names)
+}
+
+// Has reports whether the set holds the permission.
+func (s PermissionSet) Has(perm TokenPermission) bool {
+	return s.HasName(perm.String())
+}
+
+// HasName reports whether the set holds the permission named by the API,
+// known or not.
+func (s PermissionSet) HasName(name string) bool {
+	i := sort.SearchStrings(s.names, name)
+	return i < len(s.names) && s.names[i] == name
 }
 
-func setBitwise(n *uint, pos uint) {
-	*n |= 1 << pos
+// Names returns the sorted names of the permissions of the set, unknown ones
+// included.
+func (s PermissionSet) Names() []string {
+	return append([]string(nil), s.names...)
+}
+
+// Permissions returns the permissions of the set known to the package,
+// sorted.
+func (s PermissionSet) Permissions() []TokenPermission {
+	var perms []TokenPermission
+	for _, name := range s.names {
+		if perm, ok := permissionsMapping[name]; ok {
+			perms = append(perms, perm)
+		}
+	}
+	sort.Slice(perms, func(i, j int) bool { return perms[i] < perms[j] })
+	return perms
+}
+
+// Unknown returns the sorted names of the permissions of the set unknown to
+// the package.
+func (s PermissionSet) Unknown() []string {
+	var unknown []string
+	for _, name := range s.names {
+		if _, ok := permissionsMapping[name]; !ok {
+			unknown = append(unknown, name)
+		}
+	}
+	return unknown
+}
+
+// Union returns the permissions held by either set.
+func (s PermissionSet) Union(other PermissionSet) PermissionSet {
+	return newPermissionSet(append(s.Names(), other.names...))
+}
+
+// Difference returns the permissions of the set not held by the other set.
+func (s PermissionSet) Difference(other PermissionSet) PermissionSet {
+	var names []string
+	for _, name := range s.names {
+		if !other.HasName(name) {
+			names = append(names, name)
+		}
+	}
+	return PermissionSet{names: names}
+}
+
+// SubsetOf reports whether every permission of the set is held by the other
+// set, like the permissions of a subtoken in the ones of its API key.
+func (s PermissionSet) SubsetOf(other PermissionSet) bool {
+	return s.Difference(other).Len() == 0
+}
+
+// Equal reports whether both sets hold the same permissions.
+func (s PermissionSet) Equal(other PermissionSet) bool {
+	return s.SubsetOf(other) && other.SubsetOf(s)
+}
+
+// String returns the comma separated names of the permissions, parsed back
+// by ParsePermissionSet.
+func (s PermissionSet) String() string {
+	return strings.Join(s.names, ",")
+}
+
+// MarshalJSON encodes the set as the array of its names, like the
+// permissions of /tokeninfo.
+func (s PermissionSet) MarshalJSON() ([]byte, error) {
+	if s.names == nil {
+		return []byte("[]"), nil
+	}
+	return json.Marshal(s.names)
+}
+
+// UnmarshalJSON decodes the set from an array of names.
+func (s *PermissionSet) UnmarshalJSON(data []byte) error {
+	var names []string
+	if err := json.Unmarshal(data, &names); err != nil {
+		return err
+	}
+	*s = newPermissionSet(names)
+	return nil
 }
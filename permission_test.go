@@ -0,0 +1,121 @@
+package gw2api_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"reflect"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestTokenPermission_String(t *testing.T) {
+	tests := []struct {
+		perm gw2api.TokenPermission
+		want string
+	}{
+		{gw2api.TokenPermissionAccount, "account"},
+		{gw2api.TokenPermissionCharacter, "characters"},
+		{gw2api.TokenPermissionInventory, "inventories"},
+		{gw2api.TokenPermissionSize, "TokenPermission(11)"},
+	}
+	for _, tt := range tests {
+		if got := tt.perm.String(); got != tt.want {
+			t.Errorf("TokenPermission.String() = %q, want %q", got, tt.want)
+		}
+		if perm, ok := gw2api.ParseTokenPermission(tt.want); ok && perm != tt.perm {
+			t.Errorf("ParseTokenPermission(%q) = %v, want %v", tt.want, perm, tt.perm)
+		}
+	}
+	if _, ok := gw2api.ParseTokenPermission("wizardsvault"); ok {
+		t.Errorf("ParseTokenPermission(wizardsvault) = true, want false")
+	}
+}
+
+func TestPermissionSet(t *testing.T) {
+	set := gw2api.ParsePermissionSet("wallet, account,wizardsvault,account,")
+
+	if got, want := set.String(), "account,wallet,wizardsvault"; got != want {
+		t.Errorf("PermissionSet.String() = %q, want %q", got, want)
+	}
+	if got, want := set.Permissions(), []gw2api.TokenPermission{gw2api.TokenPermissionAccount, gw2api.TokenPermissionWallet}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PermissionSet.Permissions() = %v, want %v", got, want)
+	}
+	if got, want := set.Unknown(), []string{"wizardsvault"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("PermissionSet.Unknown() = %v, want %v", got, want)
+	}
+	if !set.Has(gw2api.TokenPermissionWallet) || set.Has(gw2api.TokenPermissionGuilds) || !set.HasName("wizardsvault") {
+		t.Errorf("PermissionSet.Has() does not match %v", set)
+	}
+
+	tests := []struct {
+		name string
+		got  gw2api.PermissionSet
+		want string
+	}{
+		{"union", set.Union(gw2api.NewPermissionSet(gw2api.TokenPermissionGuilds)), "account,guilds,wallet,wizardsvault"},
+		{"difference", set.Difference(gw2api.NewPermissionSet(gw2api.TokenPermissionAccount)), "wallet,wizardsvault"},
+		{"empty difference", set.Difference(set), ""},
+		{"new", gw2api.NewPermissionSet(gw2api.TokenPermissionWallet, gw2api.TokenPermissionAccount), "account,wallet"},
+	}
+	for _, tt := range tests {
+		if got := tt.got.String(); got != tt.want {
+			t.Errorf("%s = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+
+	key := gw2api.ParsePermissionSet("account,wallet,characters")
+	subtoken := gw2api.NewPermissionSet(gw2api.TokenPermissionAccount, gw2api.TokenPermissionWallet)
+	if !subtoken.SubsetOf(key) || key.SubsetOf(subtoken) || !(gw2api.PermissionSet{}).SubsetOf(subtoken) {
+		t.Errorf("PermissionSet.SubsetOf() does not match %v in %v", subtoken, key)
+	}
+}
+
+func TestPermissionSet_JSON(t *testing.T) {
+	tests := []struct {
+		set  gw2api.PermissionSet
+		want string
+	}{
+		{gw2api.ParsePermissionSet("wallet,wizardsvault,account"), `["account","wallet","wizardsvault"]`},
+		{gw2api.PermissionSet{}, `[]`},
+	}
+	for _, tt := range tests {
+		data, err := json.Marshal(tt.set)
+		if err != nil || string(data) != tt.want {
+			t.Errorf("json.Marshal(%v) = %s, %v, want %s", tt.set, data, err, tt.want)
+		}
+
+		var decoded gw2api.PermissionSet
+		if err := json.Unmarshal(data, &decoded); err != nil || !decoded.Equal(tt.set) {
+			t.Errorf("json.Unmarshal(%s) = %v, %v, want %v", data, decoded, err, tt.set)
+		}
+	}
+}
+
+func TestRequestor_OnUnknownPermission(t *testing.T) {
+	var calls int32
+	transport := tokenTransport(&calls, http.StatusOK, `{"permissions":["account","wizardsvault","pets"]}`)
+
+	var unknown []string
+	r := gw2api.NewRequestor().
+		Transport(transport).
+		OnUnknownPermission(func(name string) { unknown = append(unknown, name) }).
+		Auth("api-key")
+
+	var account gw2api.Account
+	for i := 0; i < 2; i++ {
+		if err := r.Account(&account).Err(); err != nil {
+			t.Fatalf("Requestor.Account() = %v, want no error", err)
+		}
+	}
+	if want := []string{"pets", "wizardsvault"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("OnUnknownPermission called with %v, want %v once", unknown, want)
+	}
+
+	unknown = nil
+	subtoken := newSubtoken(`{"permissions":["account","wizardsvault"]}`)
+	gw2api.NewRequestor().OnUnknownPermission(func(name string) { unknown = append(unknown, name) }).Auth(subtoken)
+	if want := []string{"wizardsvault"}; !reflect.DeepEqual(unknown, want) {
+		t.Errorf("OnUnknownPermission called with %v for a subtoken, want %v", unknown, want)
+	}
+}
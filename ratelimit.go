@@ -0,0 +1,139 @@
+package gw2api
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"time"
+)
+
+const (
+	// RateLimitBurst is the amount of requests the official API accepts
+	// in a burst before limiting an IP.
+	RateLimitBurst = 300
+	// RateLimitPerSecond is the amount of requests refilled every second
+	// by the official API.
+	RateLimitPerSecond = 5
+)
+
+// RateLimitMode defines what a Requestor does when its RateLimiter
+// has no request available.
+type RateLimitMode int
+
+const (
+	// RateLimitWait blocks until a request is available or the context of
+	// the Requestor is done.
+	RateLimitWait RateLimitMode = iota
+	// RateLimitFailFast returns ErrRateLimited immediately.
+	RateLimitFailFast
+)
+
+var ErrRateLimited = errors.New("client-side rate limit reached")
+
+// RateLimiter is a token bucket limiting the amount of requests sent to the
+// API. A RateLimiter is safe for concurrent use and can be shared between
+// several Requestors to respect the per-IP limits of the API.
+type RateLimiter struct {
+	mu       sync.Mutex
+	burst    float64
+	interval time.Duration
+	tokens   float64
+	last     time.Time
+}
+
+// NewRateLimiter returns a RateLimiter allowing a burst of `burst` requests,
+// then refilling `perSecond` requests every second.
+func NewRateLimiter(burst int, perSecond float64) *RateLimiter {
+	if burst < 1 {
+		burst = 1
+	}
+	if perSecond <= 0 {
+		perSecond = RateLimitPerSecond
+	}
+
+	return &RateLimiter{
+		burst:    float64(burst),
+		interval: time.Duration(float64(time.Second) / perSecond),
+		tokens:   float64(burst),
+		last:     time.Now(),
+	}
+}
+
+// DefaultRateLimiter returns a RateLimiter matching the limits of the
+// official API: a burst of 300 requests then 5 requests per second.
+func DefaultRateLimiter() *RateLimiter {
+	return NewRateLimiter(RateLimitBurst, RateLimitPerSecond)
+}
+
+// Allow reports whether a request can be sent now and consumes it if so.
+func (l *RateLimiter) Allow() bool {
+	ok, _ := l.reserve(false)
+	return ok
+}
+
+// Wait blocks until a request can be sent or the context is done.
+// It returns the context error in the latter case.
+func (l *RateLimiter) Wait(ctx context.Context) error {
+	ok, delay := l.reserve(true)
+	if ok {
+		return nil
+	}
+
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		l.release()
+		return ctx.Err()
+	}
+}
+
+// reserve refills the bucket and takes a token from it. When no token is
+// available, it returns false and the delay before one is. The token is only
+// taken in that case when `queue` is true.
+func (l *RateLimiter) reserve(queue bool) (bool, time.Duration) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	if l.tokens >= 1 {
+		l.tokens--
+		return true, 0
+	}
+
+	delay := time.Duration((1 - l.tokens) * float64(l.interval))
+	if queue {
+		l.tokens--
+	}
+	return false, delay
+}
+
+// available reports whether a request can be sent now, without consuming it.
+func (l *RateLimiter) available() bool {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.refill()
+	return l.tokens >= 1
+}
+
+// refill adds the tokens accumulated since the last refill to the bucket.
+// It must be called with the mutex held.
+func (l *RateLimiter) refill() {
+	now := time.Now()
+	l.tokens += float64(now.Sub(l.last)) / float64(l.interval)
+	if l.tokens > l.burst {
+		l.tokens = l.burst
+	}
+	l.last = now
+}
+
+// release gives back a token taken by a cancelled Wait.
+func (l *RateLimiter) release() {
+	l.mu.Lock()
+	l.tokens++
+	l.mu.Unlock()
+}
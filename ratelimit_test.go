@@ -0,0 +1,75 @@
+package gw2api_test
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestRateLimiter_Allow(t *testing.T) {
+	limiter := gw2api.NewRateLimiter(2, 1)
+
+	for i, want := range []bool{true, true, false} {
+		if got := limiter.Allow(); got != want {
+			t.Errorf("RateLimiter.Allow() call %d = %v, want %v", i+1, got, want)
+		}
+	}
+}
+
+func TestRateLimiter_Wait(t *testing.T) {
+	limiter := gw2api.NewRateLimiter(1, 100)
+	limiter.Allow()
+
+	start := time.Now()
+	if err := limiter.Wait(context.Background()); err != nil {
+		t.Fatalf("RateLimiter.Wait() = %v, want no error", err)
+	}
+	if elapsed := time.Since(start); elapsed < 5*time.Millisecond {
+		t.Errorf("RateLimiter.Wait() returned after %v, want to wait for a refill", elapsed)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	limiter = gw2api.NewRateLimiter(1, 0.001)
+	limiter.Allow()
+	if err := limiter.Wait(ctx); err != context.Canceled {
+		t.Errorf("RateLimiter.Wait() = %v, want %v", err, context.Canceled)
+	}
+}
+
+func TestRequestor_RateLimit(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusOK, `{"id":1}`), nil
+	})
+	limiter := gw2api.NewRateLimiter(1, 0.001)
+
+	tests := []struct {
+		name    string
+		mode    gw2api.RateLimitMode
+		wantErr error
+	}{
+		{"first request is allowed", gw2api.RateLimitFailFast, nil},
+		{"shared limiter fails fast", gw2api.RateLimitFailFast, gw2api.ErrRateLimited},
+		{"shared limiter waits until deadline", gw2api.RateLimitWait, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+			defer cancel()
+
+			var world gw2api.World
+			err := gw2api.NewRequestor().
+				Transport(transport).
+				WithContext(ctx).
+				RateLimit(limiter, tt.mode).
+				World(&world, 1).
+				Err()
+			if err != tt.wantErr {
+				t.Errorf("Requestor.World() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,17 +7,43 @@ import (
 	"fmt"
 	"net/http"
 	"net/url"
+	"strings"
 	"time"
 )
 
+// Requestor performs the requests to the API. A Requestor is never modified
+// once created: the configuration methods and the requests return a derived
+// copy, so a single Requestor can be shared between goroutines. The error of
+// a call chain is only visible on the Requestor returned by that chain.
 type Requestor struct {
+	baseURL       *url.URL
 	userAgent     string
-	schemaVersion string
+	schemaVersion Schema
 	context       context.Context
 	token         AuthToken
+	subtoken      *TokenInfo
 	lang          Lang
 	timeout       time.Duration
-	permissions   uint
+	client        *http.Client
+	limiter       *RateLimiter
+	limitMode     RateLimitMode
+	retry         *RetryPolicy
+	cache         Cache
+	cacheTTL      time.Duration
+	build         *buildWatcher
+	middlewares   []Middleware
+	metrics       MetricsCollector
+	response      *ResponseMeta
+	chunks        int
+	tokenInfos    *tokenInfoCache
+	tokenInfoTTL  time.Duration
+	rejectsAll    *endpointSet
+
+	onUnknownPermission func(name string)
+	pool                *KeyPool
+	poolAccount         string
+	poolKey             *pooledKey
+	poolToken           AuthToken
 
 	err error
 }
@@ -37,121 +63,339 @@ var (
 	ErrTooManyRequest        = errors.New("too many request: 429")
 	ErrRequireAuthentication = errors.New("API needs authentication")
 	ErrMissingScope          = errors.New("missing scope permissions")
-	BaseURL, _               = url.Parse("https://api.guildwars2.com/v2")
+	// BaseURL is the URL of the API used by the Requestors without their own
+	// base URL, see Requestor.BaseURL.
+	BaseURL, _ = url.Parse("https://api.guildwars2.com/v2")
 )
 
 func NewRequestor() *Requestor {
 	requestor := &Requestor{
 		userAgent:     "gw2api-go:0.1",
-		schemaVersion: "2021-08-14T00:00:00Z",
+		schemaVersion: DefaultSchema,
 		timeout:       15 * time.Second,
+		client:        http.DefaultClient,
+		cacheTTL:      DefaultCacheTTL,
+		chunks:        DefaultChunkConcurrency,
+		tokenInfos:    newTokenInfoCache(),
+		tokenInfoTTL:  DefaultTokenInfoTTL,
+		rejectsAll:    newEndpointSet(),
 		context:       context.TODO(),
 	}
 
 	return requestor
 }
 
+// derive returns a copy of the Requestor, modified in place by the method
+// deriving it before being returned.
+func (r *Requestor) derive() *Requestor {
+	derived := *r
+	return &derived
+}
+
+// WithContext sets the context used by every request performed by the
+// Requestor. When the context is cancelled or its deadline expires, the
+// pending request is aborted and Err() returns the context error.
+func (r *Requestor) WithContext(ctx context.Context) *Requestor {
+	if ctx == nil {
+		ctx = context.TODO()
+	}
+	r = r.derive()
+	r.context = ctx
+	return r
+}
+
+// BaseURL sets the URL of the API the Requestor sends its requests to, to use
+// a mirror, a local proxy or a fake server. The endpoints are joined to its
+// path, so the URL must include the version of the API, like
+// "http://localhost:8080/v2". The package BaseURL is used when not set.
+func (r *Requestor) BaseURL(rawURL string) *Requestor {
+	base, err := url.Parse(rawURL)
+	if err != nil {
+		return r.fail(fmt.Errorf("invalid base URL: %w", err))
+	}
+	if base.Scheme == "" || base.Host == "" {
+		return r.fail(fmt.Errorf("invalid base URL %q: missing scheme or host", rawURL))
+	}
+
+	r = r.derive()
+	r.baseURL = base
+	return r
+}
+
+// UserAgent sets the User-Agent header sent with the requests.
+func (r *Requestor) UserAgent(userAgent string) *Requestor {
+	r = r.derive()
+	r.userAgent = userAgent
+	return r
+}
+
+// SchemaVersion sets the X-Schema-Version header sent with the requests,
+// selecting the version of the payloads returned by the API. The structs
+// decode the payloads of the SchemaMilestones, DefaultSchema being used when
+// not set.
+func (r *Requestor) SchemaVersion(schema Schema) *Requestor {
+	r = r.derive()
+	r.schemaVersion = schema
+	return r
+}
+
 func (r *Requestor) Timeout(timeout time.Duration) *Requestor {
+	r = r.derive()
 	r.timeout = timeout
 	return r
 }
 
-func (r *Requestor) Auth(token string) *Requestor {
-	r.token = AuthToken(token)
-	r.permissions = 0
-	tki, err := r.TokenInfo()
-	if err != nil {
-		r.err = err
+// Client replaces the http.Client used to perform the requests. The timeout
+// of the given client replaces the one of the Requestor, unless zero: the
+// timeout of the Requestor then applies, 15 seconds by default. A later call
+// to Timeout still overrides it.
+func (r *Requestor) Client(client *http.Client) *Requestor {
+	if client == nil {
+		client = http.DefaultClient
+	}
+	r = r.derive()
+	r.client = client
+	if client.Timeout != 0 {
+		r.timeout = client.Timeout
 	}
+	return r
+}
+
+// Transport replaces the http.RoundTripper used to perform the requests,
+// useful to configure proxies, TLS or connection pooling limits.
+func (r *Requestor) Transport(transport http.RoundTripper) *Requestor {
+	client := *r.client
+	client.Transport = transport
+	r = r.derive()
+	r.client = &client
+	return r
+}
+
+// httpClient returns a copy of the configured client with the timeout of
+// the Requestor applied.
+func (r *Requestor) httpClient() *http.Client {
+	client := *r.client
+	client.Timeout = r.timeout
+	return &client
+}
+
+// RateLimit limits the requests sent by the Requestor with the given
+// RateLimiter. The same RateLimiter can be given to several Requestors to
+// share the limits of the API between them. Give a nil limiter to disable
+// the client-side rate limiting.
+func (r *Requestor) RateLimit(limiter *RateLimiter, mode RateLimitMode) *Requestor {
+	r = r.derive()
+	r.limiter = limiter
+	r.limitMode = mode
+	return r
+}
 
-	for _, perm := range tki.Permissions {
-		if p, e := permissionsMapping[perm]; e {
-			setBitwise(&r.permissions, uint(p))
-		} else {
-			// TODO new permission detected : alert Atomys
-			// log.Print("Found new permission: ", perm)
+// Auth sets the API key or subtoken sent with the requests. The permissions
+// of an API key are fetched from /tokeninfo by the first request requiring
+// them, then cached, see TokenInfo. The claims of a subtoken are read from
+// the token itself, see ParseSubtoken: the requests are refused without
+// calling the API once it is expired, or when their endpoint is not one of
+// its urls.
+func (r *Requestor) Auth(token string) *Requestor {
+	var subtoken *TokenInfo
+	if isSubtoken(token) {
+		info, err := ParseSubtoken(token)
+		if err != nil {
+			return r.fail(err)
 		}
+		r.reportUnknownPermissions(info)
+		subtoken = &info
 	}
 
+	r = r.derive()
+	r.token = AuthToken(token)
+	r.subtoken = subtoken
+	r.poolKey = nil
+	r.poolToken = ""
 	return r
 }
 
 func (r *Requestor) Lang(lang Lang) *Requestor {
+	r = r.derive()
 	r.lang = lang
 	return r
 }
 
+// Err returns the error of the call chain which returned the Requestor.
 func (r *Requestor) Err() error {
-	err := r.err
-	r.err = nil
-	return err
+	return r.err
+}
+
+// fail returns a copy of the Requestor holding the error.
+func (r *Requestor) fail(err error) *Requestor {
+	r = r.derive()
+	r.err = err
+	return r
 }
 
 func (r *Requestor) needPerms(perms ...TokenPermission) *Requestor {
+	if r.err != nil {
+		return r
+	}
+
 	if r.token == "" {
-		r.err = ErrRequireAuthentication
-		goto Return
+		return r.fail(ErrRequireAuthentication)
 	}
 
+	info, err := r.TokenInfo()
+	if err != nil {
+		return r.fail(err)
+	}
 	for _, perm := range perms {
-		if perm >= 1 && !getBitwise(r.permissions, uint(perm)) {
-			r.err = ErrMissingScope
-			goto Return
+		if !info.HasPermission(perm) {
+			return r.fail(ErrMissingScope)
 		}
 	}
 
-Return:
 	return r
 }
 
-func (r *Requestor) request(endpoint string, queryParams url.Values, v interface{}) {
+// request performs the request on a copy of the Requestor, returned with the
+// response and the error of the request.
+func (r *Requestor) request(endpoint string, queryParams url.Values, v interface{}) *Requestor {
 	// dont perform if an error is already
 	// present
 	if r.err != nil {
-		return
+		return r
+	}
+	if err := r.checkSubtoken(endpoint); err != nil {
+		return r.fail(err)
+	}
+	if err := r.takePoolKey(); err != nil {
+		return r.fail(err)
 	}
 
-	url := *BaseURL
-	url.Path += endpoint
+	r = r.derive()
+	r.perform(endpoint, queryParams, v)
+	r.releasePoolKey()
+	return r
+}
 
-	if queryParams != nil {
-		url.RawQuery = queryParams.Encode()
+// perform performs the request, storing its response and error in the
+// Requestor. It must only be called on a Requestor owned by the caller.
+func (r *Requestor) perform(endpoint string, queryParams url.Values, v interface{}) {
+	info := &RequestInfo{Endpoint: endpoint, Query: queryParams}
+	defer r.after(info, time.Now())
+
+	if err := r.context.Err(); err != nil {
+		r.err = err
+		return
 	}
 
-	req, err := http.NewRequest(http.MethodGet, url.String(), nil)
+	req, err := http.NewRequest(http.MethodGet, r.endpointURL(endpoint, queryParams).String(), nil)
 	if err != nil {
 		r.err = err
 		return
 	}
 
-	if r.token != "" {
-		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", r.token))
+	if token := r.authToken(); token != "" {
+		req.Header.Set("Authorization", fmt.Sprintf("Bearer %s", token))
 	}
-	req.Header.Set("X-Schema-Version", r.schemaVersion)
+	req.Header.Set("X-Schema-Version", string(r.schemaVersion))
 	req.Header.Set("User-Agent", r.userAgent)
 	req.Header.Set("Accept-Language", string(r.lang))
 	req = req.WithContext(r.context)
+	info.Request = req
 
-	response, err := http.DefaultClient.Do(req)
+	key := r.cacheKey(req.URL.String())
+	cached, fresh := r.cachedEntry(req, key)
+	if fresh {
+		r.response = newResponseMeta(http.StatusOK, cached.Header, true)
+		info.BytesDecoded = len(cached.Body)
+		if err = json.Unmarshal(cached.Body, &v); err != nil {
+			info.DecodeErr = err
+			r.err = err
+		}
+		return
+	}
+
+	response, attempts, err := r.do(req, info)
 	if err != nil {
-		r.err = err
+		if ctxErr := r.context.Err(); ctxErr != nil {
+			r.err = ctxErr
+			return
+		}
+		r.err = attemptsErr(attempts, err)
 		return
 	}
+	defer response.Body.Close()
+	r.response = newResponseMeta(response.StatusCode, responseHeader(response, cached), response.StatusCode == http.StatusNotModified)
 
 	switch response.StatusCode {
-	case http.StatusOK, http.StatusNotModified:
-		if err = json.NewDecoder(response.Body).Decode(&v); err != nil {
-			r.err = err
+	case http.StatusOK, http.StatusPartialContent, http.StatusNotModified:
+		body, err := r.readBody(response, key, cached)
+		if err != nil {
+			r.err = r.contextErr(err)
+			return
 		}
-	case http.StatusTooManyRequests:
-		r.err = ErrTooManyRequest
-		return
-	default:
-		var apiErr *APIError
-		if err = json.NewDecoder(response.Body).Decode(&apiErr); err != nil {
+		if len(body) == 0 {
+			return
+		}
+		info.BytesDecoded = len(body)
+		if err = json.Unmarshal(body, &v); err != nil {
+			info.DecodeErr = err
 			r.err = err
 			return
 		}
-		r.err = apiErr
+		if response.StatusCode == http.StatusPartialContent {
+			r.err = partialResultErr(endpoint, queryParams, body)
+		}
+	default:
+		r.err = attemptsErr(attempts, newAPIError(endpoint, response))
+	}
+}
+
+// endpointURL returns the URL of the endpoint, joined to the path of the base
+// URL with a single slash between them.
+func (r *Requestor) endpointURL(endpoint string, queryParams url.Values) *url.URL {
+	base := r.baseURL
+	if base == nil {
+		base = BaseURL
 	}
+
+	url := *base
+	url.Path = strings.TrimSuffix(base.Path, "/") + "/" + strings.TrimPrefix(endpoint, "/")
+	url.RawPath = ""
+	if queryParams != nil {
+		url.RawQuery = queryParams.Encode()
+	}
+	return &url
+}
+
+// waitRateLimit takes a request from the RateLimiter of the Requestor,
+// waiting or failing fast depending on the configured RateLimitMode.
+func (r *Requestor) waitRateLimit() error {
+	return r.waitLimiter(r.limiter)
+}
+
+// waitLimiter takes a request from the limiter, nil when not limited, waiting
+// or failing fast depending on the configured RateLimitMode.
+func (r *Requestor) waitLimiter(limiter *RateLimiter) error {
+	if limiter == nil {
+		return nil
+	}
+
+	if r.limitMode == RateLimitFailFast {
+		if !limiter.Allow() {
+			return ErrRateLimited
+		}
+		return nil
+	}
+
+	return limiter.Wait(r.context)
+}
+
+// contextErr returns the error of the Requestor context when it is done,
+// so cancellation and deadline expiry surface as the standard context errors.
+// Otherwise the given error is returned untouched.
+func (r *Requestor) contextErr(err error) error {
+	if ctxErr := r.context.Err(); ctxErr != nil {
+		return ctxErr
+	}
+	return err
 }
@@ -0,0 +1,257 @@
+package gw2api_test
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+type roundTripFunc func(req *http.Request) (*http.Response, error)
+
+func (f roundTripFunc) RoundTrip(req *http.Request) (*http.Response, error) {
+	return f(req)
+}
+
+func jsonResponse(req *http.Request, status int, body string) *http.Response {
+	return &http.Response{
+		StatusCode: status,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}
+}
+
+func TestRequestor_Transport(t *testing.T) {
+	var calls int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		return jsonResponse(req, http.StatusOK, `{"id":2101,"name":"Jade Sea [FR]","population":"High"}`), nil
+	})
+
+	var world gw2api.World
+	if err := gw2api.NewRequestor().Transport(transport).World(&world, 2101).Err(); err != nil {
+		t.Fatalf("Requestor.World() = %v, want no error", err)
+	}
+	if calls != 1 {
+		t.Errorf("transport called %d times, want 1", calls)
+	}
+	if world.Name != "Jade Sea [FR]" {
+		t.Errorf("World.Name = %q, want %q", world.Name, "Jade Sea [FR]")
+	}
+}
+
+func TestRequestor_Client(t *testing.T) {
+	var userAgent string
+	client := &http.Client{Transport: roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		userAgent = req.Header.Get("User-Agent")
+		return jsonResponse(req, http.StatusOK, `[2101]`), nil
+	})}
+
+	var ids []int
+	if err := gw2api.NewRequestor().Client(client).WorldIDs(&ids).Err(); err != nil {
+		t.Fatalf("Requestor.WorldIDs() = %v, want no error", err)
+	}
+	if userAgent == "" {
+		t.Errorf("custom client was not used")
+	}
+}
+
+func TestRequestor_BaseURL(t *testing.T) {
+	var requested string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = req.URL.String()
+		return jsonResponse(req, http.StatusOK, `{"id":2101}`), nil
+	})
+	r := gw2api.NewRequestor().Transport(transport)
+
+	tests := []struct {
+		name    string
+		baseURL string
+		want    string
+		wantErr bool
+	}{
+		{"mirror", "https://mirror.example.com/v2", "https://mirror.example.com/v2/worlds?id=2101", false},
+		{"trailing slash", "https://mirror.example.com/v2/", "https://mirror.example.com/v2/worlds?id=2101", false},
+		{"proxy path", "http://localhost:8080/proxy/gw2/v2", "http://localhost:8080/proxy/gw2/v2/worlds?id=2101", false},
+		{"escaped path", "http://localhost:8080/my%20proxy/v2", "http://localhost:8080/my%20proxy/v2/worlds?id=2101", false},
+		{"missing scheme", "mirror.example.com/v2", "", true},
+		{"malformed", "http://[::1", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = ""
+			var world gw2api.World
+			if err := r.BaseURL(tt.baseURL).World(&world, 2101).Err(); (err != nil) != tt.wantErr {
+				t.Fatalf("Requestor.World() = %v, want error %v", err, tt.wantErr)
+			}
+			if requested != tt.want {
+				t.Errorf("requested %q, want %q", requested, tt.want)
+			}
+		})
+	}
+
+	var world gw2api.World
+	if err := r.World(&world, 2101).Err(); err != nil {
+		t.Fatalf("Requestor.World() = %v, want no error", err)
+	}
+	if want := gw2api.BaseURL.String() + "/worlds?id=2101"; requested != want {
+		t.Errorf("requestor without base URL requested %q, want %q", requested, want)
+	}
+}
+
+func TestRequestor_Headers(t *testing.T) {
+	var header http.Header
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		header = req.Header
+		return jsonResponse(req, http.StatusOK, `[2101]`), nil
+	})
+
+	var ids []int
+	r := gw2api.NewRequestor().Transport(transport).UserAgent("my-app/1.0").SchemaVersion("2019-12-19T00:00:00Z")
+	if err := r.WorldIDs(&ids).Err(); err != nil {
+		t.Fatalf("Requestor.WorldIDs() = %v, want no error", err)
+	}
+	if got := header.Get("User-Agent"); got != "my-app/1.0" {
+		t.Errorf("User-Agent = %q, want %q", got, "my-app/1.0")
+	}
+	if got := header.Get("X-Schema-Version"); got != "2019-12-19T00:00:00Z" {
+		t.Errorf("X-Schema-Version = %q, want %q", got, "2019-12-19T00:00:00Z")
+	}
+}
+
+func TestRequestor_Timeout(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	var world gw2api.World
+	err := gw2api.NewRequestor().Transport(transport).Timeout(10*time.Millisecond).World(&world, 2101).Err()
+	if err == nil {
+		t.Fatalf("Requestor.World() = nil, want timeout error")
+	}
+}
+
+func TestRequestor_ClientTimeout(t *testing.T) {
+	// The transport answers after 100ms, unless cancelled before.
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		select {
+		case <-req.Context().Done():
+			return nil, req.Context().Err()
+		case <-time.After(100 * time.Millisecond):
+			return jsonResponse(req, http.StatusOK, `{"id":2101}`), nil
+		}
+	})
+
+	tests := []struct {
+		name      string
+		requestor *gw2api.Requestor
+		wantErr   bool
+	}{
+		{"client timeout", gw2api.NewRequestor().Client(&http.Client{Transport: transport, Timeout: 10 * time.Millisecond}), true},
+		{"requestor timeout", gw2api.NewRequestor().Timeout(10 * time.Millisecond).Client(&http.Client{Transport: transport}), true},
+		{"timeout after the client", gw2api.NewRequestor().Client(&http.Client{Transport: transport, Timeout: 10 * time.Millisecond}).Timeout(time.Minute), false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var world gw2api.World
+			if err := tt.requestor.World(&world, 2101).Err(); (err != nil) != tt.wantErr {
+				t.Errorf("Requestor.World() = %v, want error %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestor_WithContext(t *testing.T) {
+	blocking := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		<-req.Context().Done()
+		return nil, req.Context().Err()
+	})
+
+	cancelled, cancel := context.WithCancel(context.Background())
+	cancel()
+	expired, cancelExpired := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancelExpired()
+
+	tests := []struct {
+		name    string
+		ctx     context.Context
+		wantErr error
+	}{
+		{"cancelled context", cancelled, context.Canceled},
+		{"expired context", expired, context.DeadlineExceeded},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var world gw2api.World
+			err := gw2api.NewRequestor().Transport(blocking).WithContext(tt.ctx).World(&world, 2101).Err()
+			if err != tt.wantErr {
+				t.Errorf("Requestor.World() = %v, want %v", err, tt.wantErr)
+			}
+		})
+	}
+}
+
+func TestRequestor_Derive(t *testing.T) {
+	var langs []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		langs = append(langs, req.Header.Get("Accept-Language"))
+		return jsonResponse(req, http.StatusOK, `{"id":2101}`), nil
+	})
+
+	base := gw2api.NewRequestor().Transport(transport)
+	french := base.Lang(gw2api.LangFR)
+
+	var world gw2api.World
+	base.World(&world, 2101)
+	french.World(&world, 2101)
+	if want := []string{"", "fr"}; fmt.Sprint(langs) != fmt.Sprint(want) {
+		t.Errorf("Accept-Language = %q, want %q", langs, want)
+	}
+}
+
+func TestRequestor_ConcurrentUse(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		id := req.URL.Query().Get("id")
+		if id == "0" {
+			return jsonResponse(req, http.StatusNotFound, `{"text":"no such id"}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, fmt.Sprintf(`{"id":%s,"name":"%s"}`, id, req.Header.Get("Accept-Language"))), nil
+	})
+
+	r := gw2api.NewRequestor().Transport(transport)
+	langs := []gw2api.Lang{gw2api.LangFR, gw2api.LangEN, gw2api.LangDE}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 30; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+
+			var world gw2api.World
+			lang := langs[i%len(langs)]
+			err := r.Lang(lang).World(&world, i).Err()
+			switch {
+			case i == 0 && !errors.Is(err, gw2api.ErrNotFound):
+				t.Errorf("World(0) = %v, want %v", err, gw2api.ErrNotFound)
+			case i != 0 && err != nil:
+				t.Errorf("World(%d) = %v, want no error", i, err)
+			case i != 0 && world.Name != string(lang):
+				t.Errorf("World(%d).Name = %q, want %q", i, world.Name, lang)
+			}
+		}(i)
+	}
+	wg.Wait()
+
+	if err := r.Err(); err != nil {
+		t.Errorf("shared Requestor.Err() = %v, want no error", err)
+	}
+}
@@ -0,0 +1,90 @@
+package gw2api
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+)
+
+// ResponseMeta describes the response received by the last request of a
+// Requestor.
+type ResponseMeta struct {
+	// The HTTP status of the response, like 200 or 206.
+	StatusCode int
+	// The headers of the response.
+	Header http.Header
+	// True when the response has been served from the cache, either
+	// without reaching the API or after a 304 Not Modified.
+	Cached bool
+	// The language of the response, from the Content-Language header.
+	ContentLanguage string
+	// The amount of objects returned, from the X-Result-Count header.
+	ResultCount int
+	// The total amount of objects available, from the X-Result-Total header.
+	ResultTotal int
+	// The links of the Link header, by relation (next, previous, self...).
+	Links map[string]string
+	// The rate limit reported by the X-Rate-Limit-Limit header,
+	// or -1 when absent.
+	RateLimit int
+	// The remaining requests reported by the X-Rate-Limit-Remaining header,
+	// or -1 when absent.
+	RateLimitRemaining int
+}
+
+// LastResponse returns the metadata of the response received by the request
+// which returned the Requestor, or nil when no response has been received.
+func (r *Requestor) LastResponse() *ResponseMeta {
+	return r.response
+}
+
+// newResponseMeta returns the metadata of a response with its headers.
+func newResponseMeta(statusCode int, header http.Header, cached bool) *ResponseMeta {
+	if header == nil {
+		header = http.Header{}
+	}
+
+	intHeader := func(name string, fallback int) int {
+		n, err := strconv.Atoi(header.Get(name))
+		if err != nil {
+			return fallback
+		}
+		return n
+	}
+
+	return &ResponseMeta{
+		StatusCode:         statusCode,
+		Header:             header,
+		Cached:             cached,
+		ContentLanguage:    header.Get("Content-Language"),
+		ResultCount:        intHeader("X-Result-Count", 0),
+		ResultTotal:        intHeader("X-Result-Total", 0),
+		Links:              parseLinks(header.Get("Link")),
+		RateLimit:          intHeader("X-Rate-Limit-Limit", -1),
+		RateLimitRemaining: intHeader("X-Rate-Limit-Remaining", -1),
+	}
+}
+
+// parseLinks parses a Link header like
+//
+//	</v2/items?page=1>; rel=next, </v2/items?page=0>; rel=self
+//
+// into a map of URLs by relation.
+func parseLinks(header string) map[string]string {
+	links := make(map[string]string)
+	for _, link := range strings.Split(header, ",") {
+		parts := strings.Split(link, ";")
+		target := strings.Trim(strings.TrimSpace(parts[0]), "<>")
+		if target == "" {
+			continue
+		}
+
+		for _, param := range parts[1:] {
+			param = strings.TrimSpace(param)
+			if strings.HasPrefix(param, "rel=") {
+				links[strings.Trim(strings.TrimPrefix(param, "rel="), `"`)] = target
+			}
+		}
+	}
+	return links
+}
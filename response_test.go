@@ -0,0 +1,54 @@
+package gw2api_test
+
+import (
+	"net/http"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestRequestor_LastResponse(t *testing.T) {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		response := jsonResponse(req, http.StatusOK, `[{"id":1},{"id":2}]`)
+		response.Header.Set("Content-Language", "fr")
+		response.Header.Set("X-Result-Count", "2")
+		response.Header.Set("X-Result-Total", "12")
+		response.Header.Set("X-Rate-Limit-Limit", "600")
+		response.Header.Set("Link", "</v2/titles?page=1>; rel=next, </v2/titles?page=0>; rel=self")
+		return response, nil
+	})
+
+	r := gw2api.NewRequestor().Transport(transport)
+	if r.LastResponse() != nil {
+		t.Fatalf("Requestor.LastResponse() = %+v before any request, want nil", r.LastResponse())
+	}
+
+	var titles []*gw2api.Title
+	response := r.Lang(gw2api.LangFR).Titles(&titles, 1, 2).LastResponse()
+	if response == nil {
+		t.Fatalf("Requestor.LastResponse() = nil, want the response metadata")
+	}
+
+	tests := []struct {
+		name string
+		got  interface{}
+		want interface{}
+	}{
+		{"StatusCode", response.StatusCode, http.StatusOK},
+		{"Cached", response.Cached, false},
+		{"ContentLanguage", response.ContentLanguage, "fr"},
+		{"ResultCount", response.ResultCount, 2},
+		{"ResultTotal", response.ResultTotal, 12},
+		{"RateLimit", response.RateLimit, 600},
+		{"RateLimitRemaining", response.RateLimitRemaining, -1},
+		{"Links next", response.Links["next"], "/v2/titles?page=1"},
+		{"Links self", response.Links["self"], "/v2/titles?page=0"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if tt.got != tt.want {
+				t.Errorf("ResponseMeta.%s = %v, want %v", tt.name, tt.got, tt.want)
+			}
+		})
+	}
+}
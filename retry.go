@@ -0,0 +1,170 @@
+package gw2api
+
+import (
+	"fmt"
+	"io"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy defines how a Requestor retries the requests failing with a
+// transient status: 429, 502, 503 and 504. Only idempotent GET requests
+// are retried.
+type RetryPolicy struct {
+	// The maximum amount of attempts, the first one included.
+	MaxAttempts int
+	// The delay before the first retry, doubled on each following retry.
+	// A random jitter of up to half of the delay is removed from it.
+	BaseDelay time.Duration
+	// The maximum delay between two attempts. The Retry-After header sent
+	// by the API takes precedence over the computed delay and this maximum.
+	MaxDelay time.Duration
+	// OnAttempt is called after each attempt with its number (starting at 1),
+	// the response received and the transport error, if any.
+	OnAttempt func(attempt int, response *http.Response, err error)
+}
+
+// RetryError is returned when a request still fails after being retried.
+// It wraps the error of the last attempt.
+type RetryError struct {
+	Attempts int
+	Err      error
+}
+
+func (e *RetryError) Error() string {
+	return fmt.Sprintf("request failed after %d attempts: %v", e.Attempts, e.Err)
+}
+
+func (e *RetryError) Unwrap() error {
+	return e.Err
+}
+
+// attemptsErr wraps the error in a RetryError when the request has been
+// retried.
+func attemptsErr(attempts int, err error) error {
+	if attempts > 1 {
+		return &RetryError{Attempts: attempts, Err: err}
+	}
+	return err
+}
+
+// DefaultRetryPolicy returns a RetryPolicy doing up to 3 attempts, waiting
+// 500ms then 1s between them.
+func DefaultRetryPolicy() RetryPolicy {
+	return RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   500 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+	}
+}
+
+// Retry enables retries with the given policy on the Requestor.
+// Give a policy with MaxAttempts lower than 2 to disable the retries.
+func (r *Requestor) Retry(policy RetryPolicy) *Requestor {
+	r = r.derive()
+	r.retry = &policy
+	return r
+}
+
+// maxAttempts returns the amount of attempts allowed for the request.
+func (p *RetryPolicy) maxAttempts(req *http.Request) int {
+	if p == nil || p.MaxAttempts < 1 || req.Method != http.MethodGet {
+		return 1
+	}
+	return p.MaxAttempts
+}
+
+// delay returns the time to wait before the attempt following `attempt`.
+func (p *RetryPolicy) delay(attempt int, response *http.Response) time.Duration {
+	if d, ok := retryAfter(response); ok {
+		return d
+	}
+
+	d := p.BaseDelay << (attempt - 1)
+	if d <= 0 || (p.MaxDelay > 0 && d > p.MaxDelay) {
+		d = p.MaxDelay
+	}
+	if d > 0 {
+		d -= time.Duration(rand.Int63n(int64(d)/2 + 1))
+	}
+	return d
+}
+
+// retryableStatus reports whether a response status is transient.
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests,
+		http.StatusBadGateway,
+		http.StatusServiceUnavailable,
+		http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// retryAfter parses the Retry-After header of the response, given either
+// in seconds or as an HTTP date.
+func retryAfter(response *http.Response) (time.Duration, bool) {
+	value := response.Header.Get("Retry-After")
+	if value == "" {
+		return 0, false
+	}
+
+	if seconds, err := strconv.Atoi(value); err == nil && seconds >= 0 {
+		return time.Duration(seconds) * time.Second, true
+	}
+
+	if date, err := http.ParseTime(value); err == nil {
+		d := time.Until(date)
+		if d < 0 {
+			d = 0
+		}
+		return d, true
+	}
+
+	return 0, false
+}
+
+// do sends the request, retrying it according to the RetryPolicy of the
+// Requestor. Every attempt goes through the Before hooks of the middlewares,
+// then the RateLimiter. It returns the last response received and the amount
+// of attempts done.
+func (r *Requestor) do(req *http.Request, info *RequestInfo) (*http.Response, int, error) {
+	maxAttempts := r.retry.maxAttempts(req)
+
+	for attempt := 1; ; attempt++ {
+		info.Attempt = attempt
+		if response := r.before(info); response != nil {
+			return response, attempt, nil
+		}
+
+		if err := r.waitRateLimit(); err != nil {
+			return nil, attempt - 1, err
+		}
+
+		response, err := r.httpClient().Do(req)
+		if response != nil && response.StatusCode == http.StatusTooManyRequests {
+			info.RateLimited++
+		}
+		if r.retry != nil && r.retry.OnAttempt != nil {
+			r.retry.OnAttempt(attempt, response, err)
+		}
+		if err != nil || attempt >= maxAttempts || !retryableStatus(response.StatusCode) {
+			return response, attempt, err
+		}
+
+		delay := r.retry.delay(attempt, response)
+		_, _ = io.Copy(io.Discard, response.Body)
+		response.Body.Close()
+
+		timer := time.NewTimer(delay)
+		select {
+		case <-timer.C:
+		case <-r.context.Done():
+			timer.Stop()
+			return nil, attempt, r.context.Err()
+		}
+	}
+}
@@ -0,0 +1,88 @@
+package gw2api_test
+
+import (
+	"errors"
+	"net/http"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+func TestRequestor_Retry(t *testing.T) {
+	tests := []struct {
+		name         string
+		statuses     []int
+		wantAttempts int
+		wantErr      error
+	}{
+		{"success without retry", []int{200}, 1, nil},
+		{"success after transient errors", []int{503, 429, 200}, 3, nil},
+		{"gives up after max attempts", []int{429, 429, 429, 200}, 3, gw2api.ErrTooManyRequest},
+		{"does not retry other statuses", []int{404, 200}, 1, &gw2api.APIError{}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls, attempts int
+			transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+				status := tt.statuses[calls]
+				calls++
+				if status == http.StatusOK {
+					return jsonResponse(req, status, `{"id":1}`), nil
+				}
+				response := jsonResponse(req, status, `{"text":"error"}`)
+				response.Header.Set("Retry-After", "0")
+				return response, nil
+			})
+
+			policy := gw2api.DefaultRetryPolicy()
+			policy.OnAttempt = func(attempt int, response *http.Response, err error) {
+				attempts = attempt
+			}
+
+			var world gw2api.World
+			err := gw2api.NewRequestor().Transport(transport).Retry(policy).World(&world, 1).Err()
+			if attempts != tt.wantAttempts {
+				t.Errorf("Requestor.World() attempts = %d, want %d", attempts, tt.wantAttempts)
+			}
+
+			switch want := tt.wantErr.(type) {
+			case nil:
+				if err != nil {
+					t.Errorf("Requestor.World() = %v, want no error", err)
+				}
+			case *gw2api.APIError:
+				if !errors.As(err, &want) {
+					t.Errorf("Requestor.World() = %v, want an APIError", err)
+				}
+			default:
+				var retryErr *gw2api.RetryError
+				if !errors.Is(err, want) || !errors.As(err, &retryErr) || retryErr.Attempts != tt.wantAttempts {
+					t.Errorf("Requestor.World() = %v, want %v after %d attempts", err, want, tt.wantAttempts)
+				}
+			}
+		})
+	}
+}
+
+func TestRequestor_RetryBackoff(t *testing.T) {
+	var calls int
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		calls++
+		if calls == 1 {
+			return jsonResponse(req, http.StatusBadGateway, `{}`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{"id":1}`), nil
+	})
+
+	policy := gw2api.RetryPolicy{MaxAttempts: 2, BaseDelay: 20 * time.Millisecond}
+	start := time.Now()
+
+	var world gw2api.World
+	if err := gw2api.NewRequestor().Transport(transport).Retry(policy).World(&world, 1).Err(); err != nil {
+		t.Fatalf("Requestor.World() = %v, want no error", err)
+	}
+	if elapsed := time.Since(start); elapsed < 10*time.Millisecond {
+		t.Errorf("Requestor.World() retried after %v, want a backoff of at least 10ms", elapsed)
+	}
+}
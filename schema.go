@@ -0,0 +1,67 @@
+package gw2api
+
+import (
+	"time"
+)
+
+// Schema is a version of the payloads returned by the API, requested with
+// the X-Schema-Version header. The API answers with the payloads of the
+// latest schema which is not newer than the requested one.
+type Schema string
+
+const (
+	// Schema20190221 adds last_modified to /v2/account.
+	Schema20190221 Schema = "2019-02-21T00:00:00Z"
+	// Schema20191219 adds the build and equipment tabs to /v2/characters,
+	// the skills and specializations moving into the build tabs.
+	Schema20191219 Schema = "2019-12-19T00:00:00Z"
+	// Schema20210814 is the schema requested by default.
+	Schema20210814 Schema = "2021-08-14T00:00:00Z"
+	// Schema20240720 replaces wvw_rank of /v2/account by the wvw object,
+	// giving the WvW team of the account along with its rank.
+	Schema20240720 Schema = "2024-07-20T01:00:00Z"
+
+	// DefaultSchema is the schema requested by a new Requestor.
+	DefaultSchema = Schema20210814
+)
+
+// SchemaMilestone describes a schema changing the payloads decoded by the
+// library.
+type SchemaMilestone struct {
+	Schema Schema
+	// The endpoints whose payloads change with the schema.
+	Endpoints []string
+}
+
+// SchemaMilestones lists the schemas supported by the library, from the
+// oldest to the newest. The structs decode the payloads of each of them.
+var SchemaMilestones = []SchemaMilestone{
+	{Schema: Schema20190221, Endpoints: []string{"/account"}},
+	{Schema: Schema20191219, Endpoints: []string{"/characters"}},
+	{Schema: Schema20210814},
+	{Schema: Schema20240720, Endpoints: []string{"/account"}},
+}
+
+// Supported reports whether the schema is one of the SchemaMilestones.
+func (s Schema) Supported() bool {
+	for _, milestone := range SchemaMilestones {
+		if milestone.Schema == s {
+			return true
+		}
+	}
+	return false
+}
+
+// Before reports whether the schema is older than the other. Schemas which
+// are not valid RFC 3339 timestamps are compared as strings.
+func (s Schema) Before(other Schema) bool {
+	t, err := time.Parse(time.RFC3339, string(s))
+	if err != nil {
+		return s < other
+	}
+	o, err := time.Parse(time.RFC3339, string(other))
+	if err != nil {
+		return s < other
+	}
+	return t.Before(o)
+}
@@ -0,0 +1,152 @@
+package gw2api_test
+
+import (
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+
+	"atomys.codes/gw2api-go"
+	"atomys.codes/gw2api-go/gw2apitest"
+)
+
+// schemaRequestor returns an authenticated Requestor answered with the
+// payload of the schema requested by the X-Schema-Version header.
+func schemaRequestor(t *testing.T, payloads map[gw2api.Schema]string) *gw2api.Requestor {
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/tokeninfo") {
+			return jsonResponse(req, http.StatusOK, `{"permissions":["account","characters","builds"]}`), nil
+		}
+
+		schema := gw2api.Schema(req.Header.Get("X-Schema-Version"))
+		payload, ok := payloads[schema]
+		if !ok {
+			t.Errorf("no payload for schema %q", schema)
+		}
+		return jsonResponse(req, http.StatusOK, payload), nil
+	})
+
+	r := gw2api.NewRequestor().Transport(transport).Auth("api-key")
+	if err := r.Err(); err != nil {
+		t.Fatalf("Requestor.Auth() = %v, want no error", err)
+	}
+	return r
+}
+
+func TestSchemaMilestones(t *testing.T) {
+	if !gw2api.DefaultSchema.Supported() {
+		t.Errorf("DefaultSchema %q is not supported", gw2api.DefaultSchema)
+	}
+	if gw2api.Schema("2020-01-01T00:00:00Z").Supported() {
+		t.Errorf("Schema(2020-01-01T00:00:00Z).Supported() = true, want false")
+	}
+	for i := 1; i < len(gw2api.SchemaMilestones); i++ {
+		previous, current := gw2api.SchemaMilestones[i-1].Schema, gw2api.SchemaMilestones[i].Schema
+		if !previous.Before(current) || current.Before(previous) {
+			t.Errorf("SchemaMilestones are not sorted: %q listed before %q", previous, current)
+		}
+	}
+}
+
+func TestAccount_Schemas(t *testing.T) {
+	const rank = `{"id":"account-id","wvw_rank":1200,"last_modified":"2021-08-14T10:00:00Z"}`
+	r := schemaRequestor(t, map[gw2api.Schema]string{
+		gw2api.Schema20190221: rank,
+		gw2api.Schema20191219: rank,
+		gw2api.Schema20210814: rank,
+		gw2api.Schema20240720: `{"id":"account-id","wvw":{"team_id":11001,"rank":1200},"last_modified":"2021-08-14T10:00:00Z"}`,
+	})
+
+	tests := []struct {
+		schema gw2api.Schema
+		want   gw2api.AccountWvw
+	}{
+		{gw2api.Schema20190221, gw2api.AccountWvw{Rank: 1200}},
+		{gw2api.Schema20191219, gw2api.AccountWvw{Rank: 1200}},
+		{gw2api.Schema20210814, gw2api.AccountWvw{Rank: 1200}},
+		{gw2api.Schema20240720, gw2api.AccountWvw{TeamID: 11001, Rank: 1200}},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.schema), func(t *testing.T) {
+			var account gw2api.Account
+			if err := r.SchemaVersion(tt.schema).Account(&account).Err(); err != nil {
+				t.Fatalf("Requestor.Account() = %v, want no error", err)
+			}
+			if account.WvwRank != tt.want.Rank || account.Wvw != tt.want {
+				t.Errorf("Account wvw = %d, %+v, want %d, %+v", account.WvwRank, account.Wvw, tt.want.Rank, tt.want)
+			}
+		})
+	}
+}
+
+func TestCharacter_Schemas(t *testing.T) {
+	const specializations = `{"pve":[{"id":53,"traits":[1,2,3]}],"pvp":[{"id":53,"traits":[1,2,3]}],"wvw":[{"id":53,"traits":[1,2,3]}]}`
+	const skills = `{"pve":{"heal":10527,"utilities":[10546,10533,10689],"elite":10646},"pvp":{"heal":10527,"utilities":[10546,10533,10689],"elite":10646},"wvw":{"heal":10527,"utilities":[10546,10533,10689],"elite":10646}}`
+	const legacy = `{"name":"Fake Character","specializations":` + specializations + `,"skills":` + skills + `}`
+	const tabs = `{"name":"Fake Character","active_build_tab":2,"active_equipment_tab":1,"build_tabs_unlocked":2,"equipment_tabs_unlocked":1,` +
+		`"build_tabs":[{"tab":1,"is_active":false,"build":{"name":"Other"}},` +
+		`{"tab":2,"is_active":true,"build":{"name":"Power","specializations":[{"id":53,"traits":[1,2,3]}],"skills":{"heal":10527,"utilities":[10546,10533,10689],"elite":10646}}}],` +
+		`"equipment_tabs":[{"tab":1,"name":"Gear","is_active":true,"equipment":[{"id":48085,"slot":"Helm","location":"Equipped","tabs":[1]}]}]}`
+
+	r := schemaRequestor(t, map[gw2api.Schema]string{
+		gw2api.Schema20190221: legacy,
+		gw2api.Schema20191219: tabs,
+		gw2api.Schema20210814: tabs,
+		gw2api.Schema20240720: tabs,
+	})
+
+	skill := gw2api.CharacterSkill{Heal: 10527, Utilities: []int{10546, 10533, 10689}, Elite: 10646}
+	specialization := []gw2api.CharacterSpecialization{{ID: 53, Traits: []int{1, 2, 3}}}
+	tests := []struct {
+		schema        gw2api.Schema
+		wantBuildTabs int
+		wantEquipment int
+	}{
+		{gw2api.Schema20190221, 0, 0},
+		{gw2api.Schema20191219, 2, 1},
+		{gw2api.Schema20210814, 2, 1},
+		{gw2api.Schema20240720, 2, 1},
+	}
+	for _, tt := range tests {
+		t.Run(string(tt.schema), func(t *testing.T) {
+			var character gw2api.CharacterSummary
+			if err := r.SchemaVersion(tt.schema).Character(&character, "Fake Character").Err(); err != nil {
+				t.Fatalf("Requestor.Character() = %v, want no error", err)
+			}
+			if character.Name != "Fake Character" {
+				t.Errorf("Character.Name = %q, want %q", character.Name, "Fake Character")
+			}
+			if want := (gw2api.CharacterSkills{Pve: skill, Pvp: skill, Wvw: skill}); !reflect.DeepEqual(character.Skills, want) {
+				t.Errorf("Character.Skills = %+v, want %+v", character.Skills, want)
+			}
+			if want := (gw2api.CharacterSpecializations{Pve: specialization, Pvp: specialization, Wvw: specialization}); !reflect.DeepEqual(character.Specializations, want) {
+				t.Errorf("Character.Specializations = %+v, want %+v", character.Specializations, want)
+			}
+			if len(character.BuildTabs) != tt.wantBuildTabs {
+				t.Errorf("Character.BuildTabs has %d tabs, want %d", len(character.BuildTabs), tt.wantBuildTabs)
+			}
+			if len(character.EquipmentTabs) != tt.wantEquipment {
+				t.Errorf("Character.EquipmentTabs has %d tabs, want %d", len(character.EquipmentTabs), tt.wantEquipment)
+			}
+		})
+	}
+}
+
+func TestCharacters_SchemaFixture(t *testing.T) {
+	want := []gw2api.CharacterTraining{{ID: 50, Spent: 90, Done: true}}
+	for _, milestone := range gw2api.SchemaMilestones {
+		t.Run(string(milestone.Schema), func(t *testing.T) {
+			var characters []*gw2api.CharacterSummary
+			r := newRequestor().SchemaVersion(milestone.Schema).Auth(gw2apitest.KeyValid)
+			if err := r.AllCharacters(&characters).Err(); err != nil {
+				t.Fatalf("Requestor.AllCharacters() = %v, want no error", err)
+			}
+			if len(characters) != 1 || characters[0].Name != "Fake Character" {
+				t.Fatalf("Requestor.AllCharacters() = %+v, want the fixture character", characters)
+			}
+			if !reflect.DeepEqual(characters[0].Training, want) {
+				t.Errorf("Character.Training = %+v, want %+v", characters[0].Training, want)
+			}
+		})
+	}
+}
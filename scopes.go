@@ -0,0 +1,186 @@
+package gw2api
+
+import (
+	"sort"
+)
+
+// EndpointScopes are the scopes of an authenticated endpoint of the API.
+type EndpointScopes struct {
+	// The path template of the endpoint, like "/characters/:name/core", see
+	// RequestMetrics.Endpoint.
+	Endpoint string
+	// The permissions the API key must grant to request the endpoint.
+	Scopes []TokenPermission
+	// True when the endpoint is public, the scopes only giving access to more
+	// fields. Its scopes are not checked before requesting it.
+	Optional bool
+}
+
+func requires(perms ...TokenPermission) []TokenPermission {
+	return append([]TokenPermission{TokenPermissionAccount}, perms...)
+}
+
+// scopeRegistry lists the authenticated endpoints, with the scopes checked by
+// the Resources, Endpoints and Paginators before requesting them. The
+// endpoints missing from the registry are public.
+var scopeRegistry = []EndpointScopes{
+	{Endpoint: "/account", Scopes: requires()},
+	{Endpoint: "/account/achievements", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/bank", Scopes: requires(TokenPermissionInventory)},
+	{Endpoint: "/account/buildstorage", Scopes: requires()},
+	{Endpoint: "/account/dailycrafting", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/dungeons", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/dyes", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/emotes", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/finishers", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/gliders", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/home", Scopes: requires()},
+	{Endpoint: "/account/home/cats", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/home/nodes", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/inventory", Scopes: requires(TokenPermissionInventory)},
+	{Endpoint: "/account/legendaryarmory", Scopes: requires(TokenPermissionInventory, TokenPermissionUnlocks)},
+	{Endpoint: "/account/luck", Scopes: requires(TokenPermissionProgression, TokenPermissionUnlocks)},
+	{Endpoint: "/account/mailcarriers", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/mapchests", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/masteries", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/mastery/points", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/materials", Scopes: requires(TokenPermissionInventory)},
+	{Endpoint: "/account/minis", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/mounts", Scopes: requires()},
+	{Endpoint: "/account/mounts/skins", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/mounts/types", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/novelties", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/outfits", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/pvp/heroes", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/raids", Scopes: requires(TokenPermissionProgression)},
+	{Endpoint: "/account/recipes", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/skins", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/titles", Scopes: requires(TokenPermissionUnlocks)},
+	{Endpoint: "/account/wallet", Scopes: requires(TokenPermissionWallet)},
+	{Endpoint: "/account/worldbosses", Scopes: requires(TokenPermissionProgression)},
+
+	{Endpoint: "/characters", Scopes: requires(TokenPermissionCharacter)},
+	{Endpoint: "/characters/:name/backstory", Scopes: requires(TokenPermissionCharacter)},
+	{Endpoint: "/characters/:name/core", Scopes: requires(TokenPermissionCharacter)},
+	{Endpoint: "/characters/:name/crafting", Scopes: requires(TokenPermissionCharacter)},
+	{Endpoint: "/characters/:name/equipment", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds, TokenPermissionInventory)},
+	{Endpoint: "/characters/:name/heropoints", Scopes: requires(TokenPermissionCharacter, TokenPermissionProgression)},
+	{Endpoint: "/characters/:name/inventory", Scopes: requires(TokenPermissionCharacter, TokenPermissionInventory)},
+	{Endpoint: "/characters/:name/quests", Scopes: requires(TokenPermissionCharacter, TokenPermissionProgression)},
+	{Endpoint: "/characters/:name/recipes", Scopes: requires(TokenPermissionCharacter, TokenPermissionInventory)},
+	{Endpoint: "/characters/:name/sab", Scopes: requires(TokenPermissionCharacter)},
+	{Endpoint: "/characters/:name/skills", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/specializations", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/training", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/buildtabs", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/buildtabs/active", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/buildtabs/:tab", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/equipmenttabs", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/equipmenttabs/active", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+	{Endpoint: "/characters/:name/equipmenttabs/:tab", Scopes: requires(TokenPermissionCharacter, TokenPermissionBuilds)},
+
+	{Endpoint: "/commerce/delivery", Scopes: requires(TokenPermissionTradingpost)},
+	{Endpoint: "/commerce/transactions/current/buys", Scopes: requires(TokenPermissionTradingpost)},
+	{Endpoint: "/commerce/transactions/current/sells", Scopes: requires(TokenPermissionTradingpost)},
+	{Endpoint: "/commerce/transactions/history/buys", Scopes: requires(TokenPermissionTradingpost)},
+	{Endpoint: "/commerce/transactions/history/sells", Scopes: requires(TokenPermissionTradingpost)},
+
+	{Endpoint: "/createsubtoken", Scopes: requires()},
+
+	{Endpoint: "/guild/:id", Scopes: requires(TokenPermissionGuilds), Optional: true},
+	{Endpoint: "/guild/:id/log", Scopes: requires(TokenPermissionGuilds)},
+	{Endpoint: "/guild/:id/members", Scopes: requires(TokenPermissionGuilds)},
+	{Endpoint: "/guild/:id/ranks", Scopes: requires(TokenPermissionGuilds)},
+	{Endpoint: "/guild/:id/stash", Scopes: requires(TokenPermissionGuilds)},
+	{Endpoint: "/guild/:id/storage", Scopes: requires(TokenPermissionGuilds)},
+	{Endpoint: "/guild/:id/treasury", Scopes: requires(TokenPermissionGuilds)},
+	{Endpoint: "/guild/:id/upgrades", Scopes: requires(TokenPermissionGuilds)},
+}
+
+// scopesByEndpoint indexes the scopeRegistry by endpoint.
+var scopesByEndpoint = func() map[string]EndpointScopes {
+	index := make(map[string]EndpointScopes, len(scopeRegistry))
+	for _, entry := range scopeRegistry {
+		index[entry.Endpoint] = entry
+	}
+	return index
+}()
+
+// ScopeRegistry returns the authenticated endpoints of the API wrapped by the
+// package, with their scopes, sorted by endpoint. The other endpoints are
+// public.
+func ScopeRegistry() []EndpointScopes {
+	registry := make([]EndpointScopes, len(scopeRegistry))
+	for i, entry := range scopeRegistry {
+		entry.Scopes = append([]TokenPermission(nil), entry.Scopes...)
+		registry[i] = entry
+	}
+	sort.Slice(registry, func(i, j int) bool { return registry[i].Endpoint < registry[j].Endpoint })
+	return registry
+}
+
+// RequiredScopes returns the permissions an API key must grant to request
+// the endpoint, given as a path like "/characters/My Character/core" or as
+// its template. It returns nil for the public endpoints.
+func RequiredScopes(endpoint string) []TokenPermission {
+	entry, ok := scopesByEndpoint[endpointTemplate(endpoint)]
+	if !ok || entry.Optional {
+		return nil
+	}
+	return append([]TokenPermission(nil), entry.Scopes...)
+}
+
+// CallableEndpoints returns the endpoints of the ScopeRegistry whose scopes
+// are all granted by the permissions, sorted. The public endpoints are not
+// listed, any key can call them.
+func CallableEndpoints(perms ...TokenPermission) []string {
+	granted := make(map[TokenPermission]bool, len(perms))
+	for _, perm := range perms {
+		granted[perm] = true
+	}
+
+	var endpoints []string
+Registry:
+	for _, entry := range scopeRegistry {
+		for _, scope := range entry.Scopes {
+			if !granted[scope] {
+				continue Registry
+			}
+		}
+		endpoints = append(endpoints, entry.Endpoint)
+	}
+	sort.Strings(endpoints)
+	return endpoints
+}
+
+// CallableEndpoints returns the endpoints of the ScopeRegistry the token
+// given to Auth can call, see the package CallableEndpoints. The endpoints of
+// a subtoken are also limited to its urls.
+func (r *Requestor) CallableEndpoints() ([]string, error) {
+	info, err := r.TokenInfo()
+	if err != nil {
+		return nil, err
+	}
+
+	endpoints := CallableEndpoints(info.PermissionSet().Permissions()...)
+	if len(info.URLs) == 0 {
+		return endpoints, nil
+	}
+
+	allowed := make(map[string]bool, len(info.URLs))
+	for _, u := range info.URLs {
+		allowed[endpointTemplate(subtokenEndpoint(u))] = true
+	}
+	limited := endpoints[:0]
+	for _, endpoint := range endpoints {
+		if allowed[endpoint] {
+			limited = append(limited, endpoint)
+		}
+	}
+	return limited, nil
+}
+
+// authorizeEndpoint checks the scopes the registry requires for the endpoint.
+func (r *Requestor) authorizeEndpoint(endpoint string) *Requestor {
+	return authorize(r, RequiredScopes(endpoint))
+}
@@ -0,0 +1,229 @@
+package gw2api_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+	"atomys.codes/gw2api-go/gw2apitest"
+)
+
+func TestRequiredScopes(t *testing.T) {
+	tests := []struct {
+		endpoint string
+		want     []gw2api.TokenPermission
+	}{
+		{"/account", []gw2api.TokenPermission{gw2api.TokenPermissionAccount}},
+		{"/account/emotes", []gw2api.TokenPermission{gw2api.TokenPermissionAccount, gw2api.TokenPermissionUnlocks}},
+		{"/characters/My Character/buildtabs/2", []gw2api.TokenPermission{gw2api.TokenPermissionAccount, gw2api.TokenPermissionCharacter, gw2api.TokenPermissionBuilds}},
+		{"/characters/:name/buildtabs/:tab", []gw2api.TokenPermission{gw2api.TokenPermissionAccount, gw2api.TokenPermissionCharacter, gw2api.TokenPermissionBuilds}},
+		{"/guild/116E0C0E-0035-44A9-BB22-4AE3E23127E5/members", []gw2api.TokenPermission{gw2api.TokenPermissionAccount, gw2api.TokenPermissionGuilds}},
+		{"/guild/116E0C0E-0035-44A9-BB22-4AE3E23127E5", nil},
+		{"/guild/upgrades", nil},
+		{"/worlds", nil},
+	}
+	for _, tt := range tests {
+		if got := gw2api.RequiredScopes(tt.endpoint); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("RequiredScopes(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+}
+
+func TestCallableEndpoints(t *testing.T) {
+	got := gw2api.CallableEndpoints(gw2api.TokenPermissionAccount, gw2api.TokenPermissionWallet, gw2api.TokenPermissionGuilds)
+	for _, endpoint := range []string{"/account", "/account/wallet", "/guild/:id", "/guild/:id/members", "/createsubtoken"} {
+		if !contains(got, endpoint) {
+			t.Errorf("CallableEndpoints() = %v, want %s", got, endpoint)
+		}
+	}
+	for _, endpoint := range []string{"/account/bank", "/characters", "/commerce/delivery"} {
+		if contains(got, endpoint) {
+			t.Errorf("CallableEndpoints() = %v, want no %s", got, endpoint)
+		}
+	}
+	if got := gw2api.CallableEndpoints(); len(got) != 0 {
+		t.Errorf("CallableEndpoints() without permissions = %v, want none", got)
+	}
+
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		return jsonResponse(req, http.StatusOK, `{"permissions":["account","wallet","unknown"]}`), nil
+	})
+	endpoints, err := gw2api.NewRequestor().Transport(transport).Auth("api-key").CallableEndpoints()
+	if err != nil {
+		t.Fatalf("Requestor.CallableEndpoints() = %v, want no error", err)
+	}
+	if want := gw2api.CallableEndpoints(gw2api.TokenPermissionAccount, gw2api.TokenPermissionWallet); !reflect.DeepEqual(endpoints, want) {
+		t.Errorf("Requestor.CallableEndpoints() = %v, want %v", endpoints, want)
+	}
+
+	subtoken := newSubtoken(`{"permissions":["account","wallet","characters"],"urls":["/v2/account/wallet","/v2/characters/My%20Character/core"]}`)
+	endpoints, err = gw2api.NewRequestor().Auth(subtoken).CallableEndpoints()
+	if err != nil {
+		t.Fatalf("Requestor.CallableEndpoints() = %v, want no error", err)
+	}
+	if want := []string{"/account/wallet", "/characters/:name/core"}; !reflect.DeepEqual(endpoints, want) {
+		t.Errorf("Requestor.CallableEndpoints() with a subtoken = %v, want %v", endpoints, want)
+	}
+}
+
+// TestScopeRegistry_Methods calls every request method of the Requestor on
+// the fake API, to check the endpoints requiring scopes are never requested
+// without them, and each endpoint of the registry is requested by a method.
+func TestScopeRegistry_Methods(t *testing.T) {
+	server.AddKey("scopes-all", "account", "builds", "characters", "guilds", "inventories", "progression", "pvp", "tradingpost", "unlocks", "wallet")
+	server.AddKey("scopes-none")
+
+	unrequested := make(map[string]bool)
+	for _, entry := range gw2api.ScopeRegistry() {
+		unrequested[entry.Endpoint] = true
+	}
+	for method, requests := range requestedEndpoints(t, "scopes-all") {
+		for _, request := range requests {
+			if request.status == http.StatusUnauthorized || request.status == http.StatusForbidden {
+				t.Errorf("%s requests %s: %d with every scope", method, request.endpoint, request.status)
+			}
+			delete(unrequested, request.endpoint)
+		}
+	}
+	for endpoint := range unrequested {
+		t.Errorf("no method requests %s of the registry", endpoint)
+	}
+
+	for method, requests := range requestedEndpoints(t, "scopes-none") {
+		for _, request := range requests {
+			if request.status == http.StatusUnauthorized || request.status == http.StatusForbidden {
+				t.Errorf("%s requests %s without its scopes, missing from the registry", method, request.endpoint)
+			}
+		}
+	}
+}
+
+// TestRequestor_MethodsDecode calls every request method of the Requestor on
+// the fake API, to check the structs decode its fixtures.
+func TestRequestor_MethodsDecode(t *testing.T) {
+	callMethods(newRequestor().Auth(gw2apitest.KeyValid), func(method string, err error) {
+		var typeErr *json.UnmarshalTypeError
+		var syntaxErr *json.SyntaxError
+		if errors.As(err, &typeErr) || errors.As(err, &syntaxErr) {
+			t.Errorf("%s cannot decode the fixture: %v", method, err)
+		}
+	})
+}
+
+type requestedEndpoint struct {
+	endpoint string
+	status   int
+}
+
+// requestedEndpoints calls every method of the Requestor returning a
+// Requestor or a Paginator, authenticated with the key, and returns the
+// endpoint templates requested by each method with the status of the fake
+// API.
+func requestedEndpoints(t *testing.T, key string) map[string][]requestedEndpoint {
+	t.Helper()
+
+	var mu sync.Mutex
+	var requested []requestedEndpoint
+	base := server.Client().Transport
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		response, err := base.RoundTrip(req)
+		if path := strings.TrimPrefix(req.URL.Path, "/v2"); err == nil && path != "/tokeninfo" {
+			mu.Lock()
+			requested = append(requested, requestedEndpoint{endpointTemplate(path), response.StatusCode})
+			mu.Unlock()
+		}
+		return response, err
+	})
+
+	endpoints := make(map[string][]requestedEndpoint)
+	callMethods(gw2api.NewRequestor().Transport(transport).Auth(key), func(method string, err error) {
+		endpoints[method] = requested
+		requested = nil
+	})
+	return endpoints
+}
+
+// callMethods calls every method of the Requestor returning a Requestor or a
+// Paginator, fetching the first page of the latter, and gives the error of
+// each call to the function.
+func callMethods(r *gw2api.Requestor, each func(method string, err error)) {
+	requestorType := reflect.TypeOf(r)
+	paginatorType := reflect.TypeOf(&gw2api.Paginator{})
+	for i := 0; i < requestorType.NumMethod(); i++ {
+		method := requestorType.Method(i)
+		if method.Type.NumOut() != 1 || (method.Type.Out(0) != requestorType && method.Type.Out(0) != paginatorType) {
+			continue
+		}
+
+		var err error
+		out := reflect.ValueOf(r).Method(i).Call(methodArgs(method.Type))
+		if paginator, ok := out[0].Interface().(*gw2api.Paginator); ok {
+			var page []interface{}
+			paginator.Next(&page)
+			err = paginator.Err()
+		} else {
+			err = out[0].Interface().(*gw2api.Requestor).Err()
+		}
+		each(method.Name, err)
+	}
+}
+
+// methodArgName is the name given to the methods for the names and ids.
+const methodArgName = "My Character"
+
+// methodArgs returns arguments to call a method of the Requestor: names for
+// strings, 2 for numbers, new values for pointers and one value for the
+// variadic ids.
+func methodArgs(methodType reflect.Type) []reflect.Value {
+	var args []reflect.Value
+	for i := 1; i < methodType.NumIn(); i++ {
+		in := methodType.In(i)
+		if methodType.IsVariadic() && i == methodType.NumIn()-1 {
+			in = in.Elem()
+		}
+		switch {
+		case in == reflect.TypeOf(time.Time{}):
+			args = append(args, reflect.ValueOf(time.Now().Add(time.Hour)))
+		case in.Kind() == reflect.String:
+			args = append(args, reflect.ValueOf(methodArgName).Convert(in))
+		case in.Kind() >= reflect.Int && in.Kind() <= reflect.Uint64:
+			args = append(args, reflect.ValueOf(2).Convert(in))
+		case in.Kind() == reflect.Ptr:
+			args = append(args, reflect.New(in.Elem()))
+		default:
+			args = append(args, reflect.Zero(in))
+		}
+	}
+	return args
+}
+
+// endpointTemplate returns the template of the requested path, like the
+// endpoints of the registry.
+func endpointTemplate(path string) string {
+	segments := strings.Split(strings.TrimPrefix(path, "/"), "/")
+	switch {
+	case len(segments) >= 2 && segments[0] == "characters":
+		segments[1] = ":name"
+		if len(segments) == 4 && segments[3] != "active" {
+			segments[3] = ":tab"
+		}
+	case len(segments) >= 2 && segments[0] == "guild" && segments[1] == methodArgName:
+		segments[1] = ":id"
+	}
+	return "/" + strings.Join(segments, "/")
+}
+
+func contains(values []string, value string) bool {
+	for _, v := range values {
+		if v == value {
+			return true
+		}
+	}
+	return false
+}
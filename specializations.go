@@ -20,23 +20,31 @@ type Specialization struct {
 	MajorTraits []int `json:"major_traits"`
 }
 
+// SpecializationsEndpoint returns the Endpoint of /specializations.
+func SpecializationsEndpoint(r *Requestor) Endpoint[int, Specialization] {
+	return NewEndpoint[int, Specialization](r, "/specializations")
+}
+
 // This resource returns information about the specializations that are in the game.
 // Return an array of ids for each specializations.
 func (r *Requestor) SpecializationIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/specializations", &pointer)
-	return r
+	return SpecializationsEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the specializations that are in the game.
 // Return a list of response objects
 func (r *Requestor) Specializations(pointer *[]*Specialization, ids ...int) *Requestor {
-	r.collection("/specializations", &pointer, ids)
-	return r
+	return SpecializationsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the specializations that are in the game.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllSpecializations(pointer *[]*Specialization) *Requestor {
+	return SpecializationsEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the specializations that are in the game.
 // Return an object
 func (r *Requestor) Specialization(pointer *Specialization, id int) *Requestor {
-	r.singleton("/specializations", &pointer, id)
-	return r
+	return SpecializationsEndpoint(r).get(pointer, id)
 }
@@ -4,8 +4,9 @@ package gw2api
 type Story struct {
 	// The id of the story.
 	ID int `json:"id"`
-	// The id for the story season; resolvable against v2/stories/seasons.
-	Season int `json:"season"`
+	// The id for the story season, a GUID resolvable against
+	// v2/stories/seasons.
+	Season string `json:"season"`
 	// The name of the story.
 	Name string `json:"name"`
 	// The description of the story.
@@ -43,44 +44,60 @@ type StorySeason struct {
 	Stories []int `json:"stories"`
 }
 
+// StoriesEndpoint returns the Endpoint of /stories.
+func StoriesEndpoint(r *Requestor) Endpoint[int, Story] {
+	return NewEndpoint[int, Story](r, "/stories")
+}
+
 // This resource returns information about the stories that are in the game.
 // Return an array of ids for each stories.
 func (r *Requestor) StoryIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/stories", &pointer)
-	return r
+	return StoriesEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the stories that are in the game.
 // Return a list of response objects
 func (r *Requestor) Stories(pointer *[]*Story, ids ...int) *Requestor {
-	r.collection("/stories", &pointer, ids)
-	return r
+	return StoriesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the stories that are in the game.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllStories(pointer *[]*Story) *Requestor {
+	return StoriesEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the stories that are in the game.
 // Return an object
 func (r *Requestor) Story(pointer *Story, id int) *Requestor {
-	r.singleton("/stories", &pointer, id)
-	return r
+	return StoriesEndpoint(r).get(pointer, id)
+}
+
+// StorySeasonsEndpoint returns the Endpoint of /stories/seasons.
+func StorySeasonsEndpoint(r *Requestor) Endpoint[string, StorySeason] {
+	return NewEndpoint[string, StorySeason](r, "/stories/seasons")
 }
 
 // This resource returns information about the stories that are in the game.
 // Return an array of ids for each story season.
 func (r *Requestor) StorySeasonIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/stories/seasons", &pointer)
-	return r
+	return StorySeasonsEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the stories that are in the game.
 // Return a list of response objects
 func (r *Requestor) StorySeasons(pointer *[]*StorySeason, ids ...string) *Requestor {
-	r.collection("/stories/seasons", &pointer, ids)
-	return r
+	return StorySeasonsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the stories that are in the game.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllStorySeasons(pointer *[]*StorySeason) *Requestor {
+	return StorySeasonsEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the stories that are in the game.
 // Return an object
 func (r *Requestor) StorySeason(pointer *StorySeason, id string) *Requestor {
-	r.singleton("/stories/seasons", &pointer, id)
-	return r
+	return StorySeasonsEndpoint(r).get(pointer, id)
 }
@@ -0,0 +1,117 @@
+package gw2api
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+)
+
+var (
+	ErrInvalidSubtoken = errors.New("invalid subtoken")
+	ErrSubtokenExpired = errors.New("subtoken expired")
+	ErrURLNotAllowed   = errors.New("endpoint not allowed by the subtoken")
+)
+
+// subtokenClaims are the claims of the JSON Web Token of a subtoken.
+type subtokenClaims struct {
+	Subject     string   `json:"sub"`
+	IssuedAt    int64    `json:"iat"`
+	ExpiresAt   int64    `json:"exp"`
+	Permissions []string `json:"permissions"`
+	URLs        []string `json:"urls"`
+}
+
+// isSubtoken reports whether the token is a JSON Web Token, as the subtokens
+// are, rather than an API key.
+func isSubtoken(token string) bool {
+	return strings.Count(token, ".") == 2
+}
+
+// ParseSubtoken decodes the claims of a subtoken returned by CreateSubToken:
+// its permissions, expiry, creation time and allowed urls, without calling
+// the API. The signature of the token is not verified, the claims are only
+// used to refuse the requests the API would refuse.
+func ParseSubtoken(token string) (TokenInfo, error) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return TokenInfo{}, fmt.Errorf("%w: not a JSON Web Token", ErrInvalidSubtoken)
+	}
+
+	payload, err := base64.RawURLEncoding.DecodeString(strings.TrimRight(parts[1], "="))
+	if err != nil {
+		return TokenInfo{}, fmt.Errorf("%w: %v", ErrInvalidSubtoken, err)
+	}
+	var claims subtokenClaims
+	if err := json.Unmarshal(payload, &claims); err != nil {
+		return TokenInfo{}, fmt.Errorf("%w: %v", ErrInvalidSubtoken, err)
+	}
+
+	info := TokenInfo{
+		ID:          claims.Subject,
+		Permissions: claims.Permissions,
+		Type:        TokenTypeSubtoken,
+		URLs:        claims.URLs,
+	}
+	if claims.ExpiresAt != 0 {
+		info.ExpiresAt = time.Unix(claims.ExpiresAt, 0).UTC()
+	}
+	if claims.IssuedAt != 0 {
+		info.IssuedAt = time.Unix(claims.IssuedAt, 0).UTC()
+	}
+	return info, nil
+}
+
+// Expired reports whether the token is expired at the given time. API keys
+// never expire.
+func (t TokenInfo) Expired(now time.Time) bool {
+	return !t.ExpiresAt.IsZero() && !now.Before(t.ExpiresAt)
+}
+
+// AllowsEndpoint reports whether the token can be used on the endpoint, like
+// "/account". Tokens without urls are allowed on every endpoint.
+func (t TokenInfo) AllowsEndpoint(endpoint string) bool {
+	if len(t.URLs) == 0 {
+		return true
+	}
+
+	endpoint = "/" + strings.Trim(endpoint, "/")
+	for _, allowed := range t.URLs {
+		if subtokenEndpoint(allowed) == endpoint {
+			return true
+		}
+	}
+	return false
+}
+
+// subtokenEndpoint returns the endpoint of an url of a subtoken, unescaped and
+// without the version of the API: "/v2/characters/My%20Character" gives
+// "/characters/My Character".
+func subtokenEndpoint(allowed string) string {
+	if unescaped, err := url.PathUnescape(allowed); err == nil {
+		allowed = unescaped
+	}
+	allowed = "/" + strings.Trim(allowed, "/")
+	if allowed == "/v2" || strings.HasPrefix(allowed, "/v2/") {
+		allowed = "/" + strings.TrimPrefix(strings.TrimPrefix(allowed, "/v2"), "/")
+	}
+	return allowed
+}
+
+// checkSubtoken returns the error of a request to the endpoint with the
+// subtoken given to Auth, if it is expired or not allowed on the endpoint.
+func (r *Requestor) checkSubtoken(endpoint string) error {
+	if r.subtoken == nil {
+		return nil
+	}
+	if r.subtoken.Expired(time.Now()) {
+		return fmt.Errorf("%w since %s", ErrSubtokenExpired, r.subtoken.ExpiresAt.Format(time.RFC3339))
+	}
+	if !r.subtoken.AllowsEndpoint(endpoint) {
+		return fmt.Errorf("%w: %s", ErrURLNotAllowed, endpoint)
+	}
+	return nil
+}
@@ -0,0 +1,138 @@
+package gw2api_test
+
+import (
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+// newSubtoken returns an unsigned JSON Web Token holding the claims.
+func newSubtoken(claims string) string {
+	encode := base64.RawURLEncoding.EncodeToString
+	return encode([]byte(`{"alg":"HS256","typ":"JWT"}`)) + "." + encode([]byte(claims)) + ".c2lnbmF0dXJl"
+}
+
+func TestParseSubtoken(t *testing.T) {
+	tests := []struct {
+		name    string
+		token   string
+		want    gw2api.TokenInfo
+		wantErr bool
+	}{
+		{
+			name:  "claims",
+			token: newSubtoken(`{"iss":"ArenaNet","sub":"API-KEY-ID","iat":1767225600,"exp":1893456000,"permissions":["account","wallet"],"urls":["/v2/account"]}`),
+			want: gw2api.TokenInfo{
+				ID:          "API-KEY-ID",
+				Permissions: []string{"account", "wallet"},
+				Type:        gw2api.TokenTypeSubtoken,
+				ExpiresAt:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+				IssuedAt:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+				URLs:        []string{"/v2/account"},
+			},
+		},
+		{
+			name:  "no urls",
+			token: newSubtoken(`{"sub":"API-KEY-ID","permissions":["account"]}`),
+			want:  gw2api.TokenInfo{ID: "API-KEY-ID", Permissions: []string{"account"}, Type: gw2api.TokenTypeSubtoken},
+		},
+		{name: "api key", token: "5DA0FA7C-1C25-8C45-9D97-C35E0ABD3B4CD0DA0B09-5E62-4C4A-A4DE-1E8DE2A2B1B3", wantErr: true},
+		{name: "invalid payload", token: "header.!!!.signature", wantErr: true},
+		{name: "invalid claims", token: newSubtoken(`{"exp":"tomorrow"}`), wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := gw2api.ParseSubtoken(tt.token)
+			if tt.wantErr {
+				if !errors.Is(err, gw2api.ErrInvalidSubtoken) {
+					t.Errorf("ParseSubtoken() = %v, want %v", err, gw2api.ErrInvalidSubtoken)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseSubtoken() = %v, want no error", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseSubtoken() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTokenInfo_AllowsEndpoint(t *testing.T) {
+	info := gw2api.TokenInfo{URLs: []string{"/v2/account", "/v2/characters/My%20Character/core", "/v2/tokeninfo/"}}
+	tests := []struct {
+		endpoint string
+		want     bool
+	}{
+		{"/account", true},
+		{"/characters/My Character/core", true},
+		{"/tokeninfo", true},
+		{"/account/wallet", false},
+		{"/characters/My Character", false},
+	}
+	for _, tt := range tests {
+		if got := info.AllowsEndpoint(tt.endpoint); got != tt.want {
+			t.Errorf("TokenInfo.AllowsEndpoint(%q) = %v, want %v", tt.endpoint, got, tt.want)
+		}
+	}
+
+	if !(gw2api.TokenInfo{}).AllowsEndpoint("/account/wallet") {
+		t.Errorf("TokenInfo.AllowsEndpoint() without urls = false, want true")
+	}
+}
+
+func TestRequestor_AuthSubtoken(t *testing.T) {
+	var requested []string
+	transport := roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		requested = append(requested, req.URL.Path)
+		if strings.HasSuffix(req.URL.Path, "/wallet") {
+			return jsonResponse(req, http.StatusOK, `[]`), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{}`), nil
+	})
+
+	valid := newSubtoken(`{"sub":"API-KEY-ID","exp":4102444800,"permissions":["account","wallet"],"urls":["/v2/account","/v2/account/wallet"]}`)
+	expired := newSubtoken(`{"sub":"API-KEY-ID","exp":1577836800,"permissions":["account"]}`)
+
+	var account gw2api.Account
+	var wallet []*gw2api.AccountCurrency
+	var bank []*gw2api.InventoryItem
+	tests := []struct {
+		name    string
+		token   string
+		call    func(r *gw2api.Requestor) *gw2api.Requestor
+		wantErr error
+		wantReq bool
+	}{
+		{"allowed", valid, func(r *gw2api.Requestor) *gw2api.Requestor { return r.Account(&account) }, nil, true},
+		{"allowed list", valid, func(r *gw2api.Requestor) *gw2api.Requestor { return r.AccountWallet(&wallet) }, nil, true},
+		{"missing scope", valid, func(r *gw2api.Requestor) *gw2api.Requestor { return r.AccountBank(&bank) }, gw2api.ErrMissingScope, false},
+		{"not allowed", valid, func(r *gw2api.Requestor) *gw2api.Requestor { return r.Title(&gw2api.Title{}, 1) }, gw2api.ErrURLNotAllowed, false},
+		{"expired", expired, func(r *gw2api.Requestor) *gw2api.Requestor { return r.Account(&account) }, gw2api.ErrSubtokenExpired, false},
+		{"invalid", "header.!!!.signature", func(r *gw2api.Requestor) *gw2api.Requestor { return r.Account(&account) }, gw2api.ErrInvalidSubtoken, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			requested = nil
+			r := gw2api.NewRequestor().Transport(transport).Auth(tt.token)
+			if err := tt.call(r).Err(); !errors.Is(err, tt.wantErr) {
+				t.Errorf("request error = %v, want %v", err, tt.wantErr)
+			}
+			for _, path := range requested {
+				if strings.HasSuffix(path, "/tokeninfo") {
+					t.Errorf("requested /tokeninfo with a subtoken")
+				}
+			}
+			if gotReq := len(requested) > 0; gotReq != tt.wantReq {
+				t.Errorf("requested %v, want a request %v", requested, tt.wantReq)
+			}
+		})
+	}
+}
@@ -18,23 +18,31 @@ type Title struct {
 	ApRequired int `json:"ap_required"`
 }
 
+// TitlesEndpoint returns the Endpoint of /titles.
+func TitlesEndpoint(r *Requestor) Endpoint[int, Title] {
+	return NewEndpoint[int, Title](r, "/titles")
+}
+
 // This resource returns information about the titles that are in the game.
 // Return an array of ids for each type of currency.
 func (r *Requestor) TitleIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/titles", &pointer)
-	return r
+	return TitlesEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the titles that are in the game.
 // Return a list of response objects
 func (r *Requestor) Titles(pointer *[]*Title, ids ...int) *Requestor {
-	r.collection("/titles", &pointer, ids)
-	return r
+	return TitlesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the titles that are in the game.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllTitles(pointer *[]*Title) *Requestor {
+	return TitlesEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the titles that are in the game.
 // Return an object
 func (r *Requestor) Title(pointer *Title, id int) *Requestor {
-	r.singleton("/titles", &pointer, id)
-	return r
+	return TitlesEndpoint(r).get(pointer, id)
 }
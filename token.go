@@ -2,24 +2,214 @@
 package gw2api
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"net/url"
 	"strings"
+	"sync"
 	"time"
 )
 
+// The types of token given by TokenInfo.Type.
+const (
+	TokenTypeAPIKey   = "APIKey"
+	TokenTypeSubtoken = "Subtoken"
+)
+
+// DefaultTokenInfoTTL is the duration the TokenInfo of a token is cached
+// by a Requestor.
+const DefaultTokenInfoTTL = 5 * time.Minute
+
 type TokenInfo struct {
-	ID          string   `json:"id"`
-	Name        string   `json:"name"`
+	// The first half of the API key, or the API key the subtoken derives from.
+	ID string `json:"id"`
+	// The name given to the API key by the account owner.
+	Name string `json:"name"`
+	// The permissions granted to the token.
 	Permissions []string `json:"permissions"`
+	// The type of the token, TokenTypeAPIKey or TokenTypeSubtoken.
+	Type string `json:"type"`
+	// When the subtoken expires. Zero for an API key.
+	ExpiresAt time.Time `json:"expires_at"`
+	// When the subtoken has been created. Zero for an API key.
+	IssuedAt time.Time `json:"issued_at"`
+	// The endpoints the subtoken is limited to. Empty when not limited.
+	URLs []string `json:"urls"`
+}
+
+// HasPermission reports whether the token grants the permission.
+func (t TokenInfo) HasPermission(perm TokenPermission) bool {
+	return t.PermissionSet().Has(perm)
+}
+
+// PermissionSet returns the permissions granted to the token, unknown ones
+// included.
+func (t TokenInfo) PermissionSet() PermissionSet {
+	return newPermissionSet(t.Permissions)
 }
 
 type Subtoken struct {
 	Subtoken string `json:"subtoken"`
 }
 
-func (r *Requestor) TokenInfo() (token TokenInfo, err error) {
-	r.request("/tokeninfo", nil, &token)
-	return
+// TokenInfoResource returns the Resource of /tokeninfo.
+func TokenInfoResource(r *Requestor) Resource[TokenInfo] {
+	return NewResource[TokenInfo](r, "/tokeninfo")
+}
+
+// TokenInfo returns the information of the token given to Auth. The one of
+// an API key is fetched from /tokeninfo on first use, then cached for the TTL
+// of the Requestor, see TokenInfoTTL. The cache is shared by the Requestors
+// derived from the same NewRequestor. The one of a subtoken is decoded from
+// the token, without calling the API.
+func (r *Requestor) TokenInfo() (TokenInfo, error) {
+	if r.token == "" {
+		return TokenInfo{}, ErrRequireAuthentication
+	}
+	if r.subtoken != nil {
+		return *r.subtoken, nil
+	}
+
+	key := r.tokenInfoKey()
+	if info, ok := r.tokenInfos.get(key, r.tokenInfoTTL); ok {
+		return info, nil
+	}
+
+	info, err := TokenInfoResource(r).Get()
+	if err != nil {
+		return TokenInfo{}, err
+	}
+	r.reportUnknownPermissions(info)
+	r.tokenInfos.set(key, info, r.tokenInfoTTL)
+	return info, nil
+}
+
+// TokenInfoTTL sets the duration the TokenInfo of a token is cached.
+// Give a zero duration to fetch it on each authenticated request.
+func (r *Requestor) TokenInfoTTL(ttl time.Duration) *Requestor {
+	r = r.derive()
+	r.tokenInfoTTL = ttl
+	return r
+}
+
+// Permissions returns the permissions granted to the token given to Auth,
+// unknown ones included.
+func (r *Requestor) Permissions() (PermissionSet, error) {
+	info, err := r.TokenInfo()
+	if err != nil {
+		return PermissionSet{}, err
+	}
+	return info.PermissionSet(), nil
+}
+
+// OnUnknownPermission sets a function called with the name of each
+// permission unknown to the package granted to a token, when its TokenInfo is
+// fetched. Set it before Auth to be notified of the ones of a subtoken.
+func (r *Requestor) OnUnknownPermission(fn func(name string)) *Requestor {
+	r = r.derive()
+	r.onUnknownPermission = fn
+	return r
+}
+
+// reportUnknownPermissions calls the OnUnknownPermission function with the
+// unknown permissions of the token.
+func (r *Requestor) reportUnknownPermissions(info TokenInfo) {
+	if r.onUnknownPermission == nil {
+		return
+	}
+	for _, name := range info.PermissionSet().Unknown() {
+		r.onUnknownPermission(name)
+	}
+}
+
+// HasPermission reports whether the token given to Auth grants the
+// permission.
+func (r *Requestor) HasPermission(perm TokenPermission) (bool, error) {
+	info, err := r.TokenInfo()
+	if err != nil {
+		return false, err
+	}
+	return info.HasPermission(perm), nil
+}
+
+// tokenInfoKey returns the key of the token in the cache: a hash of the
+// token and the API it has been sent to.
+func (r *Requestor) tokenInfoKey() string {
+	sum := sha256.Sum256([]byte(string(r.token)))
+	return r.endpointURL("/tokeninfo", nil).String() + "|" + hex.EncodeToString(sum[:])
+}
+
+// tokenInfoCache caches the TokenInfo of the tokens.
+type tokenInfoCache struct {
+	mu      sync.Mutex
+	entries map[string]tokenInfoEntry
+}
+
+type tokenInfoEntry struct {
+	info    TokenInfo
+	fetched time.Time
+	// The time until which the entry is kept, from the TTL of the Requestor
+	// which fetched it.
+	expires time.Time
+}
+
+func newTokenInfoCache() *tokenInfoCache {
+	return &tokenInfoCache{entries: make(map[string]tokenInfoEntry)}
+}
+
+// get returns the TokenInfo cached for the key, if fetched less than ttl ago.
+func (c *tokenInfoCache) get(key string, ttl time.Duration) (TokenInfo, bool) {
+	if c == nil {
+		return TokenInfo{}, false
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	entry, ok := c.entries[key]
+	if !ok {
+		return TokenInfo{}, false
+	}
+	now := time.Now()
+	if !now.Before(entry.expires) {
+		delete(c.entries, key)
+		return TokenInfo{}, false
+	}
+	if now.Sub(entry.fetched) >= ttl {
+		return TokenInfo{}, false
+	}
+	return entry.info, true
+}
+
+// set caches the TokenInfo under the key for the ttl, evicting the expired
+// entries so the tokens no longer used don't stay in the cache.
+func (c *tokenInfoCache) set(key string, info TokenInfo, ttl time.Duration) {
+	if c == nil {
+		return
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	now := time.Now()
+	for k, entry := range c.entries {
+		if !now.Before(entry.expires) {
+			delete(c.entries, k)
+		}
+	}
+	c.entries[key] = tokenInfoEntry{info: info, fetched: now, expires: now.Add(ttl)}
+}
+
+// CreateSubTokenResource returns the Resource of /createsubtoken.
+func CreateSubTokenResource(r *Requestor, expireAt time.Time, perms []string, urls []string) Resource[Subtoken] {
+	urlValues := url.Values{
+		"expire":      []string{expireAt.Format(time.RFC3339)},
+		"permissions": []string{strings.Join(perms, ",")},
+		"urls":        []string{strings.Join(urls, ",")},
+	}
+
+	return NewResource[Subtoken](r, "/createsubtoken").
+		withQuery(urlValues)
 }
 
 // This resource allows for the creation of Subtokens; essentially API keys
@@ -40,14 +230,5 @@ func (r *Requestor) TokenInfo() (token TokenInfo, err error) {
 //
 // Return a JSON Web Token which can be used like an API key but only with the requested limitations.
 func (r *Requestor) CreateSubToken(pointer *Subtoken, expireAt time.Time, perms []string, urls []string) *Requestor {
-	urlValues := url.Values{
-		"expire":      []string{expireAt.Format(time.RFC3339)},
-		"permissions": []string{strings.Join(perms, ",")},
-		"urls":        []string{strings.Join(urls, ",")},
-	}
-
-	r.
-		needPerms(TokenPermissionAccount).
-		request("/createsubtoken", urlValues, &pointer)
-	return r
+	return CreateSubTokenResource(r, expireAt, perms, urls).get(pointer)
 }
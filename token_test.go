@@ -0,0 +1,176 @@
+package gw2api_test
+
+import (
+	"errors"
+	"net/http"
+	"reflect"
+	"strings"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"atomys.codes/gw2api-go"
+)
+
+// tokenTransport answers /tokeninfo with the given status and body, and an
+// empty object to the other endpoints. It counts the /tokeninfo requests.
+func tokenTransport(calls *int32, status int, body string) http.RoundTripper {
+	return roundTripFunc(func(req *http.Request) (*http.Response, error) {
+		if strings.HasSuffix(req.URL.Path, "/tokeninfo") {
+			atomic.AddInt32(calls, 1)
+			return jsonResponse(req, status, body), nil
+		}
+		return jsonResponse(req, http.StatusOK, `{}`), nil
+	})
+}
+
+func TestRequestor_Auth(t *testing.T) {
+	var calls int32
+	r := gw2api.NewRequestor().Transport(tokenTransport(&calls, http.StatusOK, `{"permissions":["account","wallet"]}`))
+
+	authenticated := r.Auth("api-key")
+	if err := authenticated.Err(); err != nil {
+		t.Fatalf("Requestor.Auth() = %v, want no error", err)
+	}
+	if calls != 0 {
+		t.Fatalf("Requestor.Auth() requested /tokeninfo %d times, want 0", calls)
+	}
+
+	var account gw2api.Account
+	for i := 0; i < 3; i++ {
+		if err := authenticated.Account(&account).Err(); err != nil {
+			t.Fatalf("Requestor.Account() = %v, want no error", err)
+		}
+	}
+	if err := r.Auth("api-key").Account(&account).Err(); err != nil {
+		t.Fatalf("Requestor.Account() = %v, want no error", err)
+	}
+	if calls != 1 {
+		t.Errorf("requested /tokeninfo %d times, want 1", calls)
+	}
+
+	if err := r.Auth("other-key").Account(&account).Err(); err != nil {
+		t.Fatalf("Requestor.Account() = %v, want no error", err)
+	}
+	if calls != 2 {
+		t.Errorf("requested /tokeninfo %d times for 2 tokens, want 2", calls)
+	}
+}
+
+func TestRequestor_TokenInfoTTL(t *testing.T) {
+	tests := []struct {
+		name      string
+		ttl       time.Duration
+		wantCalls int32
+	}{
+		{"cached", time.Hour, 1},
+		{"expired", 0, 3},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var calls int32
+			r := gw2api.NewRequestor().
+				Transport(tokenTransport(&calls, http.StatusOK, `{"permissions":["account"]}`)).
+				TokenInfoTTL(tt.ttl).
+				Auth("api-key")
+
+			var account gw2api.Account
+			for i := 0; i < 3; i++ {
+				if err := r.Account(&account).Err(); err != nil {
+					t.Fatalf("Requestor.Account() = %v, want no error", err)
+				}
+			}
+			if calls != tt.wantCalls {
+				t.Errorf("requested /tokeninfo %d times, want %d", calls, tt.wantCalls)
+			}
+		})
+	}
+}
+
+func TestRequestor_TokenInfoSharedCache(t *testing.T) {
+	var calls int32
+	r := gw2api.NewRequestor().Transport(tokenTransport(&calls, http.StatusOK, `{"permissions":["account"]}`))
+	cached, uncached := r.TokenInfoTTL(time.Hour), r.TokenInfoTTL(0)
+
+	// A Requestor sharing the cache with a shorter TTL does not evict the
+	// entries of the others.
+	var account gw2api.Account
+	for _, r := range []*gw2api.Requestor{cached.Auth("key-a"), uncached.Auth("key-b"), cached.Auth("key-a")} {
+		if err := r.Account(&account).Err(); err != nil {
+			t.Fatalf("Requestor.Account() = %v, want no error", err)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("requested /tokeninfo %d times, want 2", calls)
+	}
+}
+
+func TestRequestor_TokenInfoFailure(t *testing.T) {
+	var calls int32
+	r := gw2api.NewRequestor().
+		Transport(tokenTransport(&calls, http.StatusUnauthorized, `{"text":"Invalid access token"}`)).
+		Auth("invalid-key")
+
+	var account gw2api.Account
+	for i := 0; i < 2; i++ {
+		if err := r.Account(&account).Err(); !errors.Is(err, gw2api.ErrInvalidKey) {
+			t.Errorf("Requestor.Account() = %v, want %v", err, gw2api.ErrInvalidKey)
+		}
+	}
+	if calls != 2 {
+		t.Errorf("requested /tokeninfo %d times, want 2 as failures are not cached", calls)
+	}
+
+	if _, err := gw2api.NewRequestor().Permissions(); err != gw2api.ErrRequireAuthentication {
+		t.Errorf("Requestor.Permissions() without token = %v, want %v", err, gw2api.ErrRequireAuthentication)
+	}
+}
+
+func TestRequestor_Permissions(t *testing.T) {
+	const subtoken = `{"id":"token-id","name":"my key","permissions":["account","wallet","unknown"],"type":"Subtoken",` +
+		`"expires_at":"2030-01-01T00:00:00Z","issued_at":"2026-01-01T00:00:00Z","urls":["/v2/account"]}`
+	var calls int32
+	r := gw2api.NewRequestor().Transport(tokenTransport(&calls, http.StatusOK, subtoken)).Auth("subtoken")
+
+	perms, err := r.Permissions()
+	if err != nil {
+		t.Fatalf("Requestor.Permissions() = %v, want no error", err)
+	}
+	if want := gw2api.ParsePermissionSet("account,wallet,unknown"); !perms.Equal(want) {
+		t.Errorf("Requestor.Permissions() = %v, want %v", perms, want)
+	}
+
+	tests := []struct {
+		perm gw2api.TokenPermission
+		want bool
+	}{
+		{gw2api.TokenPermissionAccount, true},
+		{gw2api.TokenPermissionWallet, true},
+		{gw2api.TokenPermissionCharacter, false},
+	}
+	for _, tt := range tests {
+		if got, err := r.HasPermission(tt.perm); err != nil || got != tt.want {
+			t.Errorf("Requestor.HasPermission(%d) = %v, %v, want %v", tt.perm, got, err, tt.want)
+		}
+	}
+
+	info, err := r.TokenInfo()
+	if err != nil {
+		t.Fatalf("Requestor.TokenInfo() = %v, want no error", err)
+	}
+	want := gw2api.TokenInfo{
+		ID:          "token-id",
+		Name:        "my key",
+		Permissions: []string{"account", "wallet", "unknown"},
+		Type:        gw2api.TokenTypeSubtoken,
+		ExpiresAt:   time.Date(2030, 1, 1, 0, 0, 0, 0, time.UTC),
+		IssuedAt:    time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		URLs:        []string{"/v2/account"},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("Requestor.TokenInfo() = %+v, want %+v", info, want)
+	}
+	if calls != 1 {
+		t.Errorf("requested /tokeninfo %d times, want 1", calls)
+	}
+}
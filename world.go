@@ -7,17 +7,23 @@ type World struct {
 	Population string `json:"population"`
 }
 
+// WorldsEndpoint returns the Endpoint of /worlds.
+func WorldsEndpoint(r *Requestor) Endpoint[int, World] {
+	return NewEndpoint[int, World](r, "/worlds")
+}
+
 func (r *Requestor) World(pointer *World, id int) *Requestor {
-	r.singleton("/worlds", &pointer, id)
-	return r
+	return WorldsEndpoint(r).get(pointer, id)
 }
 
-func (r *Requestor) WorldIDs(pointer []int) *Requestor {
-	r.collectionIDs("/worlds", &pointer)
-	return r
+func (r *Requestor) WorldIDs(pointer *[]int) *Requestor {
+	return WorldsEndpoint(r).ids(pointer)
 }
 
 func (r *Requestor) Worlds(worlds *[]*World, ids ...int) *Requestor {
-	r.collection("/worlds", &worlds, ids)
-	return r
+	return WorldsEndpoint(r).getMany(worlds, ids)
+}
+
+func (r *Requestor) AllWorlds(worlds *[]*World) *Requestor {
+	return WorldsEndpoint(r).all(worlds)
 }
@@ -17,8 +17,8 @@ func TestRequestor_World(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"world with correct id", gw2api.NewRequestor(), args{gw2api.World{}, 2101}, false},
-		{"world with invalid id", gw2api.NewRequestor(), args{gw2api.World{}, 0}, true},
+		{"world with correct id", newRequestor(), args{gw2api.World{}, 2101}, false},
+		{"world with invalid id", newRequestor(), args{gw2api.World{}, 0}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,11 +39,11 @@ func TestRequestor_WorldIDs(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"WorldIDs", gw2api.NewRequestor(), args{[]int{}}, false},
+		{"WorldIDs", newRequestor(), args{[]int{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			if got := tt.requestor.WorldIDs(tt.args.worldIDs).Err(); (got != nil) != tt.wantErr {
+			if got := tt.requestor.WorldIDs(&tt.args.worldIDs).Err(); (got != nil) != tt.wantErr {
 				t.Errorf("Requestor.World() = %v, want error %v", got, tt.wantErr)
 			}
 		})
@@ -60,10 +60,10 @@ func TestRequestor_Worlds(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"all worlds", gw2api.NewRequestor(), args{nil}, true},
-		{"no worlds given", gw2api.NewRequestor(), args{[]int{}}, true},
-		{"one or more worlds", gw2api.NewRequestor(), args{[]int{2101}}, false},
-		{"one or more invalid ids", gw2api.NewRequestor(), args{[]int{-1, -5}}, true},
+		{"all worlds", newRequestor(), args{nil}, true},
+		{"no worlds given", newRequestor(), args{[]int{}}, true},
+		{"one or more worlds", newRequestor(), args{[]int{2101}}, false},
+		{"one or more invalid ids", newRequestor(), args{[]int{-1, -5}}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -5,12 +5,15 @@ type WorldBoss struct {
 	ID string `json:"id"`
 }
 
+// WorldBossesEndpoint returns the Endpoint of /worldbosses.
+func WorldBossesEndpoint(r *Requestor) Endpoint[string, WorldBoss] {
+	return NewEndpoint[string, WorldBoss](r, "/worldbosses")
+}
+
 func (r *Requestor) WorldBoss(pointer *WorldBoss, id string) *Requestor {
-	r.singleton("/worldbosses", &pointer, id)
-	return r
+	return WorldBossesEndpoint(r).get(pointer, id)
 }
 
 func (r *Requestor) WorldBosses(pointer *[]string) *Requestor {
-	r.collectionIDs("/worldbosses", &pointer)
-	return r
+	return WorldBossesEndpoint(r).ids(pointer)
 }
@@ -17,8 +17,8 @@ func TestRequestor_WorldBoss(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"worldBoss with correct id", gw2api.NewRequestor(), args{gw2api.WorldBoss{}, "the_shatterer"}, false},
-		{"worldBoss with invalid id", gw2api.NewRequestor(), args{gw2api.WorldBoss{}, "a_fuckin_barrel"}, true},
+		{"worldBoss with correct id", newRequestor(), args{gw2api.WorldBoss{}, "the_shatterer"}, false},
+		{"worldBoss with invalid id", newRequestor(), args{gw2api.WorldBoss{}, "a_fuckin_barrel"}, true},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -39,7 +39,7 @@ func TestRequestor_WorldBosses(t *testing.T) {
 		args      args
 		wantErr   bool
 	}{
-		{"WorldBosses", gw2api.NewRequestor(), args{[]string{}}, false},
+		{"WorldBosses", newRequestor(), args{[]string{}}, false},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
@@ -228,10 +228,10 @@ type WvwObjective struct {
 	MapType string `json:"map_type"`
 	// An array of three numbers representing the X, Y and Z coordinates of
 	// the objectives marker on the map.
-	Coord []int `json:"coord"`
+	Coord []float64 `json:"coord"`
 	// An array of two numbers representing the X and Y coordinates of the
 	// sector centroid.
-	LabelCoord []int `json:"label_coord"`
+	LabelCoord []float64 `json:"label_coord"`
 	// The icon link
 	Marker string `json:"marker"`
 	// The chat code for the observed objective.
@@ -242,7 +242,7 @@ type WvwObjective struct {
 
 type WvwRank struct {
 	// The id of the rank.
-	ID string `json:"id"`
+	ID int `json:"id"`
 	// The given title for the WvW rank.
 	Title string `json:"title"`
 	// The minimum WvW level required to be at this rank.
@@ -272,194 +272,266 @@ type WvwUpgradeTierUpgrade struct {
 	Icon string `json:"icon"`
 }
 
+// WvwAbilitiesEndpoint returns the Endpoint of /wvw/abilities.
+func WvwAbilitiesEndpoint(r *Requestor) Endpoint[int, WvwAbility] {
+	return NewEndpoint[int, WvwAbility](r, "/wvw/abilities")
+}
+
 // This resource returns information about the available abilities in the
 // World versus World game mode.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwAbilityIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/wvw/abilities", &pointer)
-	return r
+	return WvwAbilitiesEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the available abilities in the
 // World versus World game mode.
 // Return a list of response objects
 func (r *Requestor) WvwAbilities(pointer *[]*WvwAbility, ids ...int) *Requestor {
-	r.collection("/wvw/abilities", &pointer, ids)
-	return r
+	return WvwAbilitiesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the available abilities in the
+// World versus World game mode.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwAbilities(pointer *[]*WvwAbility) *Requestor {
+	return WvwAbilitiesEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the available abilities in the
 // World versus World game mode.
 // Return an object
 func (r *Requestor) WvwAbility(pointer *WvwAbility, id int) *Requestor {
-	r.singleton("/wvw/abilities", &pointer, id)
-	return r
+	return WvwAbilitiesEndpoint(r).get(pointer, id)
+}
+
+// WvwMatchesEndpoint returns the Endpoint of /wvw/matches.
+func WvwMatchesEndpoint(r *Requestor) Endpoint[string, WvwMatch] {
+	return NewEndpoint[string, WvwMatch](r, "/wvw/matches")
 }
 
 // This resource returns information about the available matches in the
 // World versus World game mode.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwMatchIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/wvw/matches", &pointer)
-	return r
+	return WvwMatchesEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the available matches in the
 // World versus World game mode.
 // Return a list of response objects
 func (r *Requestor) WvwMatches(pointer *[]*WvwMatch, ids ...string) *Requestor {
-	r.collection("/wvw/matches", &pointer, ids)
-	return r
+	return WvwMatchesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the available matches in the
+// World versus World game mode.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwMatches(pointer *[]*WvwMatch) *Requestor {
+	return WvwMatchesEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the available matches in the
 // World versus World game mode.
 // Return an object
 func (r *Requestor) WvwMatch(pointer *WvwMatch, id string) *Requestor {
-	r.singleton("/wvw/matches", &pointer, id)
-	return r
+	return WvwMatchesEndpoint(r).get(pointer, id)
+}
+
+// WvwMatchOverviewsEndpoint returns the Endpoint of /wvw/matches/overview.
+func WvwMatchOverviewsEndpoint(r *Requestor) Endpoint[string, WvwMatchOverview] {
+	return NewEndpoint[string, WvwMatchOverview](r, "/wvw/matches/overview")
 }
 
 // This resource returns information about the available matches overview in the
 // World versus World game mode.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwMatchOverviewIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/wvw/matches/overview", &pointer)
-	return r
+	return WvwMatchOverviewsEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the available matches overview in the
 // World versus World game mode.
 // Return a list of response objects
 func (r *Requestor) WvwMatchOverviews(pointer *[]*WvwMatchOverview, ids ...string) *Requestor {
-	r.collection("/wvw/matches/overview", &pointer, ids)
-	return r
+	return WvwMatchOverviewsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the available matches overview in the
+// World versus World game mode.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwMatchOverviews(pointer *[]*WvwMatchOverview) *Requestor {
+	return WvwMatchOverviewsEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the available matches overview in the
 // World versus World game mode.
 // Return an object
 func (r *Requestor) WvwMatchOverview(pointer *WvwMatchOverview, id string) *Requestor {
-	r.singleton("/wvw/matches/overview", &pointer, id)
-	return r
+	return WvwMatchOverviewsEndpoint(r).get(pointer, id)
+}
+
+// WvwMatchScoresEndpoint returns the Endpoint of /wvw/matches/scores.
+func WvwMatchScoresEndpoint(r *Requestor) Endpoint[string, WvwMatchScore] {
+	return NewEndpoint[string, WvwMatchScore](r, "/wvw/matches/scores")
 }
 
 // This resource returns information about the available matches scores in the
 // World versus World game mode.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwMatchScoreIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/wvw/matches/scores", &pointer)
-	return r
+	return WvwMatchScoresEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the available matches scores in the
 // World versus World game mode.
 // Return a list of response objects
 func (r *Requestor) WvwMatchScores(pointer *[]*WvwMatchScore, ids ...string) *Requestor {
-	r.collection("/wvw/matches/scores", &pointer, ids)
-	return r
+	return WvwMatchScoresEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the available matches scores in the
+// World versus World game mode.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwMatchScores(pointer *[]*WvwMatchScore) *Requestor {
+	return WvwMatchScoresEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the available matches scores in the
 // World versus World game mode.
 // Return an object
 func (r *Requestor) WvwMatchScore(pointer *WvwMatchScore, id string) *Requestor {
-	r.singleton("/wvw/matches/scores", &pointer, id)
-	return r
+	return WvwMatchScoresEndpoint(r).get(pointer, id)
+}
+
+// WvwMatchStatsEndpoint returns the Endpoint of /wvw/matches/stats.
+func WvwMatchStatsEndpoint(r *Requestor) Endpoint[string, WvwMatchStat] {
+	return NewEndpoint[string, WvwMatchStat](r, "/wvw/matches/stats")
 }
 
 // This resource returns information about the available matches stats in the
 // World versus World game mode.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwMatchStatIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/wvw/matches/stats", &pointer)
-	return r
+	return WvwMatchStatsEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the available matches stats in the
 // World versus World game mode.
 // Return a list of response objects
 func (r *Requestor) WvwMatchStats(pointer *[]*WvwMatchStat, ids ...string) *Requestor {
-	r.collection("/wvw/matches/stats", &pointer, ids)
-	return r
+	return WvwMatchStatsEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the available matches stats in the
+// World versus World game mode.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwMatchStats(pointer *[]*WvwMatchStat) *Requestor {
+	return WvwMatchStatsEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the available matches stats in the
 // World versus World game mode.
 // Return an object
 func (r *Requestor) WvwMatchStat(pointer *WvwMatchStat, id string) *Requestor {
-	r.singleton("/wvw/matches/stats", &pointer, id)
-	return r
+	return WvwMatchStatsEndpoint(r).get(pointer, id)
+}
+
+// WvwObjectivesEndpoint returns the Endpoint of /wvw/objectives.
+func WvwObjectivesEndpoint(r *Requestor) Endpoint[string, WvwObjective] {
+	return NewEndpoint[string, WvwObjective](r, "/wvw/objectives")
 }
 
 // This resource returns details about World vs. World objectives such
 // as camps, towers, and keeps.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwObjectiveIDs(pointer *[]string) *Requestor {
-	r.collectionIDs("/wvw/objectives", &pointer)
-	return r
+	return WvwObjectivesEndpoint(r).ids(pointer)
 }
 
 // This resource returns details about World vs. World objectives such
 // as camps, towers, and keeps.
 // Return a list of response objects
 func (r *Requestor) WvwObjectives(pointer *[]*WvwObjective, ids ...string) *Requestor {
-	r.collection("/wvw/objectives", &pointer, ids)
-	return r
+	return WvwObjectivesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns details about World vs. World objectives such
+// as camps, towers, and keeps.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwObjectives(pointer *[]*WvwObjective) *Requestor {
+	return WvwObjectivesEndpoint(r).all(pointer)
 }
 
 // This resource returns details about World vs. World objectives such
 // as camps, towers, and keeps.
 // Return an object
 func (r *Requestor) WvwObjective(pointer *WvwObjective, id string) *Requestor {
-	r.singleton("/wvw/objectives", &pointer, id)
-	return r
+	return WvwObjectivesEndpoint(r).get(pointer, id)
+}
+
+// WvwRanksEndpoint returns the Endpoint of /wvw/ranks.
+func WvwRanksEndpoint(r *Requestor) Endpoint[int, WvwRank] {
+	return NewEndpoint[int, WvwRank](r, "/wvw/ranks")
 }
 
 // This resource returns information about the available ranks in the
 // World versus World game mode.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwRankIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/wvw/ranks", &pointer)
-	return r
+	return WvwRanksEndpoint(r).ids(pointer)
 }
 
 // This resource returns information about the available ranks in the
 // World versus World game mode.
 // Return a list of response objects
 func (r *Requestor) WvwRanks(pointer *[]*WvwRank, ids ...int) *Requestor {
-	r.collection("/wvw/ranks", &pointer, ids)
-	return r
+	return WvwRanksEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns information about the available ranks in the
+// World versus World game mode.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwRanks(pointer *[]*WvwRank) *Requestor {
+	return WvwRanksEndpoint(r).all(pointer)
 }
 
 // This resource returns information about the available ranks in the
 // World versus World game mode.
 // Return an object
 func (r *Requestor) WvwRank(pointer *WvwRank, id int) *Requestor {
-	r.singleton("/wvw/ranks", &pointer, id)
-	return r
+	return WvwRanksEndpoint(r).get(pointer, id)
+}
+
+// WvwUpgradesEndpoint returns the Endpoint of /wvw/upgrades.
+func WvwUpgradesEndpoint(r *Requestor) Endpoint[int, WvwUpgrade] {
+	return NewEndpoint[int, WvwUpgrade](r, "/wvw/upgrades")
 }
 
 // This resource returns details about available World vs. World upgrades
 // for objectives such as camps, towers, and keeps.
 // Return an array of ids for each type of currency.
 func (r *Requestor) WvwUpgradeIDs(pointer *[]int) *Requestor {
-	r.collectionIDs("/wvw/upgrades", &pointer)
-	return r
+	return WvwUpgradesEndpoint(r).ids(pointer)
 }
 
 // This resource returns details about available World vs. World upgrades
 // for objectives such as camps, towers, and keeps.
 // Return a list of response objects
 func (r *Requestor) WvwUpgrades(pointer *[]*WvwUpgrade, ids ...int) *Requestor {
-	r.collection("/wvw/upgrades", &pointer, ids)
-	return r
+	return WvwUpgradesEndpoint(r).getMany(pointer, ids)
+}
+
+// This resource returns details about available World vs. World upgrades
+// for objectives such as camps, towers, and keeps.
+// Return the list of all response objects, using `ids=all` when supported.
+func (r *Requestor) AllWvwUpgrades(pointer *[]*WvwUpgrade) *Requestor {
+	return WvwUpgradesEndpoint(r).all(pointer)
 }
 
 // This resource returns details about available World vs. World upgrades
 // for objectives such as camps, towers, and keeps.
 // Return an object
 func (r *Requestor) WvwUpgrade(pointer *WvwUpgrade, id int) *Requestor {
-	r.singleton("/wvw/upgrades", &pointer, id)
-	return r
+	return WvwUpgradesEndpoint(r).get(pointer, id)
 }